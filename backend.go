@@ -28,6 +28,37 @@ type DBBatchTranslator interface {
 	QueryBatch(ctx context.Context, table, keyField, valueField string, keys []string) (map[string]string, error)
 }
 
+// DBCompositeTranslator DBTranslator 的复合键可选接口：db:"key=org_id+code,from=OrgID+Code" 时调用，
+// keyFields 与 key 按位置一一对应
+type DBCompositeTranslator interface {
+	QueryComposite(ctx context.Context, table string, keyFields []string, valueField string, key []string) (string, error)
+}
+
+// DBCompositeBatchTranslator 复合键的批量可选接口：返回 CompositeKey(key...) -> value
+type DBCompositeBatchTranslator interface {
+	QueryCompositeBatch(ctx context.Context, table string, keyFields []string, valueField string, keys [][]string) (map[string]string, error)
+}
+
+// DBRowTranslator 多列取值可选接口：db:"table=user,key=id,value=name+avatar" dictField:"UserName,UserAvatar" 时调用，
+// 一次取出一行的多列，返回列名 -> 值。单列键时 keyFields / key 只有一个元素
+type DBRowTranslator interface {
+	QueryRow(ctx context.Context, table string, keyFields, valueFields []string, key []string) (map[string]string, error)
+}
+
+// DBRowBatchTranslator 多列取值的批量可选接口：一次 IN 查询取出所有 key 的多列，返回 CompositeKey(key...) -> 列名 -> 值
+type DBRowBatchTranslator interface {
+	QueryRowBatch(ctx context.Context, table string, keyFields, valueFields []string, keys [][]string) (map[string]map[string]string, error)
+}
+
+// compositeSep 复合键 / 多列取值各列之间的分隔符（ASCII 单元分隔符，不会出现在普通键值里）
+const compositeSep = "\x1f"
+
+// CompositeKey 把复合键各列的值拼成一个 key（结果缓存与 DBCompositeBatchTranslator 返回的 map 都用它做键）
+func CompositeKey(values ...string) string { return strings.Join(values, compositeSep) }
+
+// SplitCompositeKey CompositeKey 的逆操作
+func SplitCompositeKey(key string) []string { return strings.Split(key, compositeSep) }
+
 // DictTableContextTranslator DictTableTranslator / DictTableTwoTranslator 的 ctx 版可选接口
 type DictTableContextTranslator interface {
 	QueryDictContext(ctx context.Context, dictType, dictKey string) (string, error)
@@ -44,6 +75,47 @@ type DictTableLoader interface {
 	LoadDict(ctx context.Context, dictType string) (map[string]string, error)
 }
 
+// DictTableTreeTranslator DictTableTranslator 的树形字典可选接口：一次查出一批 key 的节点（名称 + 上级 key），
+// tree:"region,source=dictTable" 按层调用它向上取祖先
+type DictTableTreeTranslator interface {
+	QueryNodes(ctx context.Context, dictType string, dictKeys []string) (map[string]TreeNode, error)
+}
+
+// DictTableBulkLoader DictTableTranslator / DictTableTwoTranslator 的整表预加载可选接口：一次查询取出所有字典类型，
+// 返回 dictType -> {key: value}。Config.Performance.PreloadDicts 为 ["*"] 时 Framework.Init 用它代替逐类型 LoadDict。
+type DictTableBulkLoader interface {
+	LoadAll(ctx context.Context) (map[string]map[string]string, error)
+}
+
+// CacheScoper 后端可选接口（三类 DB 后端通用）：查询结果依赖 ctx 时（如按租户过滤）实现它，
+// 返回值并入结果缓存键，不同作用域的结果互不可见。TableConfig.ContextConditions 的 SQL 后端已实现。
+type CacheScoper interface {
+	CacheScope(ctx context.Context) string
+}
+
+// disabledMark 停用标记的分隔符：标签里不会出现 NUL，缓存里存带标记的原值，翻译时再按字段选项展开
+const disabledMark = "\x00"
+
+// MarkDisabled 给后端返回的标签打上"已停用"标记（TableConfig.StatusMarkDisabled 的 SQL 后端已自动调用）。
+// 翻译时：字段标签带 disabledField= 选项则把该 bool 字段置 true、标签不变；否则标签后追加 suffix。
+// 自定义后端（DBTranslator、DictTableTranslator）想标记停用项时返回 MarkDisabled(label, suffix) 即可。
+func MarkDisabled(label, suffix string) string {
+	return disabledMark + label + disabledMark + suffix
+}
+
+// splitDisabled 拆开 MarkDisabled 的结果；未标记时原样返回、disabled=false
+func splitDisabled(v string) (label, suffix string, disabled bool) {
+	if !strings.HasPrefix(v, disabledMark) {
+		return v, "", false
+	}
+	rest := v[len(disabledMark):]
+	i := strings.Index(rest, disabledMark)
+	if i < 0 {
+		return rest, "", true
+	}
+	return rest[:i], rest[i+len(disabledMark):], true
+}
+
 // ---------------------------------------------------------------------------
 // 结果缓存：Decorator——包在 DB 后端外面。默认进程内 map；Config.Cache.Enabled 且设置了 CustomCache（如 Redis）时走它，
 // TTL 取 Config.Cache.TTL。EnableXCache(false) 相当于摘掉这层装饰器。
@@ -109,29 +181,60 @@ type lookupManager struct {
 	cache   *resultCache
 }
 
-// lookupBackend 把三类后端接口统一成 one / many / load 三个能力；many / load 为 nil 表示不支持
+// lookupBackend 把三类后端接口统一成 one / many / load / all 四个能力；many / load / all 为 nil 表示不支持。
+// scope 非 nil 时（后端实现了 CacheScoper）其返回值作为缓存键的作用域前缀
 type lookupBackend struct {
-	one  func(ctx context.Context, parts []string, key string) (string, error)
-	many func(ctx context.Context, parts []string, keys []string) (map[string]string, error)
-	load func(ctx context.Context, parts []string) (map[string]string, error)
+	one   func(ctx context.Context, parts []string, key string) (string, error)
+	many  func(ctx context.Context, parts []string, keys []string) (map[string]string, error)
+	load  func(ctx context.Context, parts []string) (map[string]string, error)
+	all   func(ctx context.Context) (map[string]map[string]string, error)
+	nodes func(ctx context.Context, parts []string, keys []string) (map[string]TreeNode, error)
+	scope func(ctx context.Context) string
 }
 
 // cacheGroup 分组的缓存键前缀；分隔符只影响缓存键，不再被反解析
 func cacheGroup(parts []string) string { return strings.Join(parts, "\x00") }
 
+// scopedGroup 带作用域的分组前缀：无作用域时就是 group 本身（缓存键与旧版一致）
+func (b *lookupBackend) scopedGroup(ctx context.Context, group string) string {
+	if b.scope == nil {
+		return group
+	}
+	if s := b.scope(ctx); s != "" {
+		return s + "\x00" + group
+	}
+	return group
+}
+
+// scope 当前后端在 ctx 下的缓存作用域（未注册 / 未实现 CacheScoper 时为空串）
+func (m *lookupManager) scope(ctx context.Context) string {
+	if b := m.backend.Load(); b != nil && b.scope != nil {
+		return b.scope(ctx)
+	}
+	return ""
+}
+
+// withScope 后端实现了 CacheScoper 时挂上 scope
+func (b *lookupBackend) withScope(backend any) *lookupBackend {
+	if cs, ok := backend.(CacheScoper); ok {
+		b.scope = cs.CacheScope
+	}
+	return b
+}
+
 func newLookupManager(name string) *lookupManager {
 	return &lookupManager{name: name, cache: newResultCache(name)}
 }
 
 func (m *lookupManager) lookup(ctx context.Context, group string, parts []string, key string) (string, error) {
-	cacheKey := group + ":" + key
-	if v, ok := m.cache.get(cacheKey); ok {
-		return v, nil
-	}
 	b := m.backend.Load()
 	if b == nil {
 		return "", fmt.Errorf("%s translator not registered", m.name)
 	}
+	cacheKey := b.scopedGroup(ctx, group) + ":" + key
+	if v, ok := m.cache.get(cacheKey); ok {
+		return v, nil
+	}
 	v, err := b.one(ctx, parts, key)
 	if err != nil {
 		return "", err
@@ -140,12 +243,22 @@ func (m *lookupManager) lookup(ctx context.Context, group string, parts []string
 	return v, nil
 }
 
+// peek 只读缓存、不查后端（预取后解出下一层 key 用）
+func (m *lookupManager) peek(ctx context.Context, group string, key string) (string, bool) {
+	b := m.backend.Load()
+	if b == nil {
+		return "", false
+	}
+	return m.cache.get(b.scopedGroup(ctx, group) + ":" + key)
+}
+
 // prefetch 批量预热：只查未命中缓存的 key；后端不支持批量则什么都不做（后续按单 key 走）
 func (m *lookupManager) prefetch(ctx context.Context, group string, parts []string, keys []string) error {
 	b := m.backend.Load()
 	if b == nil || b.many == nil || !m.cache.enabled.Load() {
 		return nil
 	}
+	prefix := b.scopedGroup(ctx, group)
 	opt := NewBatchQueryOptimizer()
 	seen := make(map[string]struct{}, len(keys))
 	for _, k := range keys {
@@ -153,13 +266,13 @@ func (m *lookupManager) prefetch(ctx context.Context, group string, parts []stri
 			continue
 		}
 		seen[k] = struct{}{}
-		if _, ok := m.cache.get(group + ":" + k); ok {
+		if _, ok := m.cache.get(prefix + ":" + k); ok {
 			continue
 		}
 		k := k // go 1.21：循环变量仍是共享的
 		opt.AddQuery(group, k, func(v string, err error) {
 			if err == nil {
-				m.cache.set(group+":"+k, v)
+				m.cache.set(prefix+":"+k, v)
 			}
 		})
 	}
@@ -178,7 +291,7 @@ func (m *lookupManager) preload(ctx context.Context, parts []string) (map[string
 	if b == nil || b.load == nil {
 		return nil, fmt.Errorf("%s translator does not support preload (implement DictTableLoader)", m.name)
 	}
-	group := cacheGroup(parts)
+	group := b.scopedGroup(ctx, cacheGroup(parts))
 	data, err := b.load(ctx, parts)
 	if err != nil {
 		return nil, err
@@ -189,6 +302,25 @@ func (m *lookupManager) preload(ctx context.Context, parts []string) (map[string
 	return data, nil
 }
 
+// preloadAll 一次取出全部分组（仅 dictTable 类，分组即 [dictType]）并预热缓存
+func (m *lookupManager) preloadAll(ctx context.Context) (map[string]map[string]string, error) {
+	b := m.backend.Load()
+	if b == nil || b.all == nil {
+		return nil, fmt.Errorf("%s translator does not support bulk preload (implement DictTableBulkLoader)", m.name)
+	}
+	data, err := b.all(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for dictType, kv := range data {
+		group := b.scopedGroup(ctx, cacheGroup([]string{dictType}))
+		for k, v := range kv {
+			m.cache.set(group+":"+k, v)
+		}
+	}
+	return data, nil
+}
+
 // ---------------------------------------------------------------------------
 // lookupTranslator：挂在字段上的翻译器（一个 struct tag 对应一个），实现 Translator + ContextTranslator
 // ---------------------------------------------------------------------------
@@ -221,22 +353,93 @@ var (
 	defaultDictTableTwoManager = newLookupManager("dictTableTwo")
 )
 
-// RegisterDBTranslator 注册数据库翻译器（可选实现 DBContextTranslator / DBBatchTranslator）
+// RegisterDBTranslator 注册数据库翻译器（可选实现 DBContextTranslator / DBBatchTranslator / DBCompositeTranslator /
+// DBCompositeBatchTranslator / DBRowTranslator / DBRowBatchTranslator / CacheScoper）。
+// 复合键字段要求实现 DBCompositeTranslator，多列取值（value=a+b）要求实现 DBRowTranslator，否则翻译时报错。
 func RegisterDBTranslator(translator DBTranslator) {
 	b := &lookupBackend{
 		one: func(ctx context.Context, p []string, key string) (string, error) {
+			keyFields, valueFields := strings.Split(p[1], "+"), strings.Split(p[2], "+")
+			if len(valueFields) > 1 {
+				rt, ok := translator.(DBRowTranslator)
+				if !ok {
+					return "", fmt.Errorf("db translator does not support multiple value columns (implement DBRowTranslator)")
+				}
+				row, err := rt.QueryRow(ctx, p[0], keyFields, valueFields, SplitCompositeKey(key))
+				if err != nil {
+					return "", err
+				}
+				return encodeRow(row, valueFields), nil
+			}
+			if len(keyFields) > 1 {
+				ct, ok := translator.(DBCompositeTranslator)
+				if !ok {
+					return "", fmt.Errorf("db translator does not support composite keys (implement DBCompositeTranslator)")
+				}
+				return ct.QueryComposite(ctx, p[0], keyFields, p[2], SplitCompositeKey(key))
+			}
 			if ct, ok := translator.(DBContextTranslator); ok {
 				return ct.QueryContext(ctx, p[0], p[1], p[2], key)
 			}
 			return translator.Query(p[0], p[1], p[2], key)
 		},
 	}
-	if bt, ok := translator.(DBBatchTranslator); ok {
+	bt, single := translator.(DBBatchTranslator)
+	cbt, composite := translator.(DBCompositeBatchTranslator)
+	rbt, rows := translator.(DBRowBatchTranslator)
+	if single || composite || rows {
+		// 某类键不支持批量时返回空结果：预取什么都不缓存，翻译时按单 key 走
 		b.many = func(ctx context.Context, p []string, keys []string) (map[string]string, error) {
+			keyFields, valueFields := strings.Split(p[1], "+"), strings.Split(p[2], "+")
+			switch {
+			case len(valueFields) > 1:
+				if !rows {
+					return map[string]string{}, nil
+				}
+				res, err := rbt.QueryRowBatch(ctx, p[0], keyFields, valueFields, splitCompositeKeys(keys))
+				if err != nil {
+					return nil, err
+				}
+				out := make(map[string]string, len(res))
+				for k, row := range res {
+					out[k] = encodeRow(row, valueFields)
+				}
+				return out, nil
+			case len(keyFields) > 1:
+				if !composite {
+					return map[string]string{}, nil
+				}
+				return cbt.QueryCompositeBatch(ctx, p[0], keyFields, p[2], splitCompositeKeys(keys))
+			case !single:
+				return map[string]string{}, nil
+			}
 			return bt.QueryBatch(ctx, p[0], p[1], p[2], keys)
 		}
 	}
-	defaultDBTranslatorManager.backend.Store(b)
+	defaultDBTranslatorManager.backend.Store(b.withScope(translator))
+}
+
+func splitCompositeKeys(keys []string) [][]string {
+	split := make([][]string, len(keys))
+	for i, k := range keys {
+		split[i] = SplitCompositeKey(k)
+	}
+	return split
+}
+
+// encodeRow 把一行的多列值按 valueFields 顺序编成一个字符串存进结果缓存（各列都为空时返回空串 = 未找到）；
+// 翻译时按 dictField 的目标字段顺序拆开
+func encodeRow(row map[string]string, valueFields []string) string {
+	values := make([]string, len(valueFields))
+	found := false
+	for i, f := range valueFields {
+		values[i] = row[f]
+		found = found || values[i] != ""
+	}
+	if !found {
+		return ""
+	}
+	return CompositeKey(values...)
 }
 
 // EnableDBCache 启用 / 禁用数据库翻译结果缓存
@@ -268,10 +471,18 @@ func dictTableBackend(translator interface {
 	if ld, ok := translator.(DictTableLoader); ok {
 		b.load = func(ctx context.Context, p []string) (map[string]string, error) { return ld.LoadDict(ctx, p[0]) }
 	}
-	return b
+	if bl, ok := translator.(DictTableBulkLoader); ok {
+		b.all = bl.LoadAll
+	}
+	if tt, ok := translator.(DictTableTreeTranslator); ok {
+		b.nodes = func(ctx context.Context, p []string, keys []string) (map[string]TreeNode, error) {
+			return tt.QueryNodes(ctx, p[0], keys)
+		}
+	}
+	return b.withScope(translator)
 }
 
-// RegisterDictTableTranslator 注册字典表翻译器（可选实现 DictTableContextTranslator / DictTableBatchTranslator / DictTableLoader）
+// RegisterDictTableTranslator 注册字典表翻译器（可选实现 DictTableContextTranslator / DictTableBatchTranslator / DictTableLoader / DictTableBulkLoader / DictTableTreeTranslator / CacheScoper）
 func RegisterDictTableTranslator(translator DictTableTranslator) {
 	defaultDictTableManager.backend.Store(dictTableBackend(translator))
 }
@@ -286,7 +497,7 @@ func createDictTableTranslator(dictType string) Translator {
 	return newLookupTranslator(defaultDictTableManager, dictType)
 }
 
-// RegisterDictTableTwoTranslator 注册双表字典翻译器（可选实现 DictTableContextTranslator / DictTableBatchTranslator / DictTableLoader）
+// RegisterDictTableTwoTranslator 注册双表字典翻译器（可选实现 DictTableContextTranslator / DictTableBatchTranslator / DictTableLoader / DictTableBulkLoader / CacheScoper）
 func RegisterDictTableTwoTranslator(translator DictTableTwoTranslator) {
 	defaultDictTableTwoManager.backend.Store(dictTableBackend(translator))
 }
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"sync/atomic"
 	"testing"
+	"time"
 )
 
 // countingDictTable 假字典表后端：记录单查 / 批查 / 预加载次数
@@ -268,3 +269,247 @@ func TestClearOneKindDoesNotTouchOthers(t *testing.T) {
 		t.Fatalf("ClearDBCache 后 dictTable 缓存应仍命中，实际查询 %d 次", n)
 	}
 }
+
+// 按租户过滤的后端：CacheScope 并入缓存键，同一个 key 不同租户各查各的，互不串
+type tenantDictTable struct {
+	countingDictTable
+}
+
+func (t *tenantDictTable) QueryDictContext(ctx context.Context, dictType, key string) (string, error) {
+	atomic.AddInt64(&t.single, 1)
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return t.data[tenant+"/"+dictType][key], nil
+}
+
+func (t *tenantDictTable) CacheScope(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return tenant
+}
+
+func TestCacheScopeIsolatesTenants(t *testing.T) {
+	be := &tenantDictTable{countingDictTable{data: map[string]map[string]string{
+		"a/level": {"1": "金牌"},
+		"b/level": {"1": "Gold"},
+	}}}
+	resetDictTableFor(t, be)
+	type Row struct {
+		Level     string `dictTable:"level" dictField:"LevelName"`
+		LevelName string
+	}
+	ctxA := context.WithValue(context.Background(), tenantKey{}, "a")
+	ctxB := context.WithValue(context.Background(), tenantKey{}, "b")
+
+	ra, rb, ra2 := &Row{Level: "1"}, &Row{Level: "1"}, &Row{Level: "1"}
+	for _, c := range []struct {
+		r   *Row
+		ctx context.Context
+	}{{ra, ctxA}, {rb, ctxB}, {ra2, ctxA}} {
+		if err := TranslateWith(c.r, WithContext(c.ctx)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if ra.LevelName != "金牌" || rb.LevelName != "Gold" || ra2.LevelName != "金牌" {
+		t.Fatalf("租户串了: a=%q b=%q a2=%q", ra.LevelName, rb.LevelName, ra2.LevelName)
+	}
+	if n := atomic.LoadInt64(&be.single); n != 2 {
+		t.Fatalf("期望每个租户各查 1 次（共 2 次），实际 %d", n)
+	}
+}
+
+// PreloadDicts: ["*"] → 一次 LoadAll，不逐类型 LoadDict，之后翻译全部命中缓存
+type bulkDictTable struct {
+	countingDictTable
+	all int64
+}
+
+func (b *bulkDictTable) LoadAll(context.Context) (map[string]map[string]string, error) {
+	atomic.AddInt64(&b.all, 1)
+	return b.data, nil
+}
+
+func TestFrameworkPreloadAll(t *testing.T) {
+	be := &bulkDictTable{countingDictTable: countingDictTable{data: map[string]map[string]string{
+		"sex":    {"1": "男"},
+		"status": {"1": "启用"},
+	}}}
+	resetDictTableFor(t, be)
+
+	cfg := *GetConfig()
+	cfg.Performance.PreloadDicts = []string{"*"}
+	fw := NewFramework(&cfg)
+	if err := fw.Init(); err != nil {
+		t.Fatal(err)
+	}
+	if a, l := atomic.LoadInt64(&be.all), atomic.LoadInt64(&be.load); a != 1 || l != 0 {
+		t.Fatalf("期望 1 次 LoadAll 0 次 LoadDict，实际 all=%d load=%d", a, l)
+	}
+	if v, ok := fw.Preloaded("status", "1"); !ok || v != "启用" {
+		t.Fatalf("Preloaded: %q %v", v, ok)
+	}
+	type Row struct {
+		Sex        string `dictTable:"sex" dictField:"SexName"`
+		SexName    string
+		Status     string `dictTable:"status" dictField:"StatusName"`
+		StatusName string
+	}
+	r := &Row{Sex: "1", Status: "1"}
+	if err := fw.Translate(r); err != nil || r.SexName != "男" || r.StatusName != "启用" {
+		t.Fatalf("翻译失败: %v %+v", err, r)
+	}
+	if n := atomic.LoadInt64(&be.single); n != 0 {
+		t.Fatalf("全量预加载后不应再单查，实际 %d 次", n)
+	}
+
+	// 后端不支持 LoadAll → Init 报错
+	resetDictTableFor(t, &countingDictTable{})
+	if err := NewFramework(&cfg).Init(); err == nil {
+		t.Fatal("后端未实现 DictTableBulkLoader 时应报错")
+	}
+}
+
+type ctxKeyPreload struct{}
+
+// 记录 LoadAll 收到的 ctx
+type ctxBulkDictTable struct {
+	bulkDictTable
+	seen any
+}
+
+func (b *ctxBulkDictTable) LoadAll(ctx context.Context) (map[string]map[string]string, error) {
+	b.seen = ctx.Value(ctxKeyPreload{})
+	return b.bulkDictTable.LoadAll(ctx)
+}
+
+// "*" 同时加载双表后端，"*" 旁的具名类型照常预加载，InitContext 的 ctx 传给查询
+func TestFrameworkPreloadAllTwoTables(t *testing.T) {
+	one := &ctxBulkDictTable{bulkDictTable: bulkDictTable{countingDictTable: countingDictTable{data: map[string]map[string]string{
+		"sex": {"1": "男"},
+	}}}}
+	resetDictTableFor(t, one)
+	two := &bulkDictTable{countingDictTable: countingDictTable{data: map[string]map[string]string{
+		"sex":   {"1": "双表-男"},
+		"level": {"1": "一级"},
+	}}}
+	RegisterDictTableTwoTranslator(two)
+	t.Cleanup(func() { defaultDictTableTwoManager.backend.Store(nil); ClearDictTableTwoCache() })
+
+	cfg := *GetConfig()
+	cfg.Performance.PreloadDicts = []string{"*", "extra"}
+	fw := NewFramework(&cfg)
+	ctx := context.WithValue(context.Background(), ctxKeyPreload{}, "t1")
+	if err := fw.InitContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if one.seen != "t1" {
+		t.Fatalf("预加载应使用 InitContext 的 ctx: %v", one.seen)
+	}
+	if a := atomic.LoadInt64(&two.all); a != 1 {
+		t.Fatalf("双表后端应调用 1 次 LoadAll，实际 %d", a)
+	}
+	if v, ok := fw.Preloaded("level", "1"); !ok || v != "一级" {
+		t.Fatalf("双表类型应预加载: %q %v", v, ok)
+	}
+	if v, _ := fw.Preloaded("sex", "1"); v != "男" {
+		t.Fatalf("同名类型应以单表为准: %q", v)
+	}
+	if l := atomic.LoadInt64(&one.load); l != 1 {
+		t.Fatalf("\"*\" 旁的具名类型应逐个预加载，实际 LoadDict %d 次", l)
+	}
+}
+
+func TestBuildQueryAllTypes(t *testing.T) {
+	q, args := DefaultTableConfig("sys_dict").BuildQueryAllTypes()
+	if q != "SELECT dict_type, dict_key, dict_value FROM sys_dict WHERE status = ?" || len(args) != 1 {
+		t.Fatalf("BuildQueryAllTypes: %s %v", q, args)
+	}
+	tc := DefaultTableConfig("sys_dict")
+	tc.StatusField = nil
+	if q, args := tc.BuildQueryAllTypes(); q != "SELECT dict_type, dict_key, dict_value FROM sys_dict" || args != nil {
+		t.Fatalf("无过滤条件时不应有 WHERE: %s %v", q, args)
+	}
+	if q, _ := DefaultDictTypeTableConfig("sys_dict_type").BuildTypeListQuery(); q != "SELECT dict_type_code FROM sys_dict_type WHERE status = ?" {
+		t.Fatalf("BuildTypeListQuery: %s", q)
+	}
+}
+
+// 停用项：后端用 MarkDisabled 标记，默认追加后缀；disabledField 选项改为写 bool 字段
+func TestDisabledEntries(t *testing.T) {
+	resetDictTableFor(t, DictTableTranslatorFunc(func(dictType, key string) (string, error) {
+		if key == "9" {
+			return MarkDisabled("旧分类", "（已停用）"), nil
+		}
+		return "新分类", nil
+	}))
+	type Order struct {
+		Cat          string `dictTable:"category" dictField:"CatName"`
+		CatName      string
+		Cat2         string `dictTable:"category,disabledField=Cat2Disabled" dictField:"Cat2Name"`
+		Cat2Name     string
+		Cat2Disabled bool
+	}
+	o := &Order{Cat: "9", Cat2: "9"}
+	if err := Translate(o); err != nil {
+		t.Fatal(err)
+	}
+	if o.CatName != "旧分类（已停用）" || o.Cat2Name != "旧分类" || !o.Cat2Disabled {
+		t.Fatalf("停用项处理不对: %+v", o)
+	}
+	o2 := &Order{Cat: "1", Cat2: "1"}
+	if err := Translate(o2); err != nil || o2.CatName != "新分类" || o2.Cat2Name != "新分类" || o2.Cat2Disabled {
+		t.Fatalf("启用项不应被标记: %v %+v", err, o2)
+	}
+}
+
+// 时间版本字典：asOf 选项按单据日期取标签，预取按生效版本分组（每个版本一次批量）
+type versionedDictTable struct {
+	batch int64
+}
+
+func (v *versionedDictTable) label(ctx context.Context, key string) string {
+	at, _ := AsOf(ctx)
+	if key == "A" && at.Year() < 2025 {
+		return "电子产品"
+	}
+	return "数码"
+}
+
+func (v *versionedDictTable) QueryDict(string, string) (string, error) { return "", nil }
+func (v *versionedDictTable) QueryDictContext(ctx context.Context, _, key string) (string, error) {
+	return v.label(ctx, key), nil
+}
+func (v *versionedDictTable) QueryDictBatch(ctx context.Context, _ string, keys []string) (map[string]string, error) {
+	atomic.AddInt64(&v.batch, 1)
+	out := map[string]string{}
+	for _, k := range keys {
+		out[k] = v.label(ctx, k)
+	}
+	return out, nil
+}
+func (v *versionedDictTable) CacheScope(ctx context.Context) string {
+	at, _ := AsOf(ctx)
+	return at.Format("2006")
+}
+
+func TestAsOfVersionedLookup(t *testing.T) {
+	be := &versionedDictTable{}
+	resetDictTableFor(t, be)
+	type Doc struct {
+		Category     string `dictTable:"category,asOf=CreatedAt" dictField:"CategoryName"`
+		CategoryName string
+		CreatedAt    time.Time
+	}
+	docs := make([]Doc, 20)
+	for i := range docs {
+		docs[i].Category = "A"
+		docs[i].CreatedAt = time.Date(2024+i%2, 3, i+1, 0, 0, 0, 0, time.UTC)
+	}
+	if err := Translate(&docs); err != nil {
+		t.Fatal(err)
+	}
+	if docs[0].CategoryName != "电子产品" || docs[1].CategoryName != "数码" {
+		t.Fatalf("应按单据日期取标签: %q %q", docs[0].CategoryName, docs[1].CategoryName)
+	}
+	if n := atomic.LoadInt64(&be.batch); n != 2 {
+		t.Fatalf("期望按 2 个生效版本各批量 1 次，实际 %d", n)
+	}
+}
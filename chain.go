@@ -0,0 +1,179 @@
+package dict
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// maxChainSteps 链式翻译的最大步数，超过的 chain 标签整体忽略
+const maxChainSteps = 8
+
+// chainStep 链上的一步：翻译器 + 传给它的标签值；id 是原始步骤文本，用来识别重复的步骤
+type chainStep struct {
+	tr  Translator
+	tag string
+	id  string
+}
+
+// chainTranslator chain 标签的翻译器：上一步的结果作为下一步的 key，
+// 如 chain:"db:table=dept,key=id,value=parent_id | db:table=dept,key=id,value=name" 把 DeptID 翻成上级部门名称。
+// 任一步结果为空即停止（结果为空）；同一步遇到重复的 key（数据成环）也停止。中间结果带停用标记时取原标签。
+type chainTranslator struct {
+	steps []chainStep
+}
+
+// parseChainTag 解析 chain 标签：步骤以 | 分隔，每步写成 "来源:标签值"，
+// 来源是 db / dictTable / dictTableTwo / enum / dict / translate。任一步无效或超过 maxChainSteps 返回 nil
+func (dm *DictManager) parseChainTag(tag string) *chainTranslator {
+	parts := strings.Split(tag, "|")
+	if len(parts) < 2 || len(parts) > maxChainSteps {
+		return nil
+	}
+	c := &chainTranslator{steps: make([]chainStep, 0, len(parts))}
+	for _, part := range parts {
+		st, ok := dm.parseStep(part)
+		if !ok {
+			return nil
+		}
+		c.steps = append(c.steps, st)
+	}
+	return c
+}
+
+// parseStep 解析一个 "来源:标签值" 步骤（chain 的每一步、switch 的每个分支）。
+// db 步骤只支持单列键、单列取值
+func (dm *DictManager) parseStep(part string) (chainStep, bool) {
+	part = strings.TrimSpace(part)
+	kind, value, ok := strings.Cut(part, ":")
+	if !ok || value == "" {
+		return chainStep{}, false
+	}
+	st := chainStep{tag: value, id: part}
+	switch kind {
+	case "db":
+		spec, ok := parseDBSpec(value)
+		if !ok || spec.from != nil || strings.Contains(spec.valueField, "+") {
+			return chainStep{}, false
+		}
+		st.tr = createDBTranslator(spec.table, spec.keyField, spec.valueField)
+	case "dictTable":
+		st.tr = createDictTableTranslator(value)
+	case "dictTableTwo":
+		st.tr = createDictTableTwoTranslator(value)
+	case "enum":
+		st.tr = DefaultEnumTranslator()
+	case "dict":
+		st.tr = dictStep{dm: dm}
+	case "range":
+		st.tr = rangeTranslator{}
+	case "translate":
+		st.tr = dm.loadReg().translators[value]
+	}
+	return st, st.tr != nil
+}
+
+// dictStep 链上的内存字典一步（按调用时的注册表查，与 dict 标签一致）
+type dictStep struct{ dm *DictManager }
+
+func (d dictStep) Translate(value any, _ string, tagValue string) (string, error) {
+	return d.dm.loadReg().dicts[tagValue][fmt.Sprintf("%v", value)], nil
+}
+
+func (c *chainTranslator) Translate(value any, fieldName string, tagValue string) (string, error) {
+	return c.TranslateContext(context.Background(), value, fieldName, tagValue)
+}
+
+func (c *chainTranslator) TranslateContext(ctx context.Context, value any, fieldName string, _ string) (string, error) {
+	key := fmt.Sprintf("%v", value)
+	var seen []string // 每步的输入 key；同一步（同一翻译器 + 标签）再遇到同一个 key 说明数据成环
+	for i, st := range c.steps {
+		if key == "" {
+			return "", nil
+		}
+		for j := 0; j < i; j++ {
+			if seen[j] == key && c.steps[j].id == st.id {
+				return "", nil
+			}
+		}
+		seen = append(seen, key)
+		v, err := st.translate(ctx, key, fieldName)
+		if err != nil {
+			return "", err
+		}
+		if i < len(c.steps)-1 {
+			v, _, _ = splitDisabled(v)
+		}
+		key = v
+	}
+	return key, nil
+}
+
+func (st chainStep) translate(ctx context.Context, key string, fieldName string) (string, error) {
+	if ct, ok := st.tr.(ContextTranslator); ok {
+		return ct.TranslateContext(ctx, key, fieldName, st.tag)
+	}
+	return st.tr.Translate(key, fieldName, st.tag)
+}
+
+// scope 链上各 DB 类步骤的缓存作用域拼起来，作为收集模式的分组依据
+func (c *chainTranslator) scope(ctx context.Context) string {
+	var b strings.Builder
+	for _, st := range c.steps {
+		if lt, ok := st.tr.(*lookupTranslator); ok {
+			b.WriteString(lt.mgr.scope(ctx))
+			b.WriteByte(0)
+		}
+	}
+	return b.String()
+}
+
+// prefetch 按层预取：每层的 key 一次批量查询（DB 类步骤），再从缓存解出下一层的 key，链有几层就查几次。
+// 后端不支持批量时缓存里解不出下一层，后续层交给翻译时逐个查
+func (c *chainTranslator) prefetch(ctx context.Context, keys []string) error {
+	for i, st := range c.steps {
+		keys = dedupeKeys(keys)
+		if len(keys) == 0 {
+			return nil
+		}
+		if lt, ok := st.tr.(*lookupTranslator); ok {
+			if err := lt.mgr.prefetch(ctx, lt.group, lt.parts, keys); err != nil {
+				return err
+			}
+		}
+		if i == len(c.steps)-1 {
+			return nil
+		}
+		next := make([]string, 0, len(keys))
+		for _, k := range keys {
+			var v string
+			if lt, ok := st.tr.(*lookupTranslator); ok {
+				v, _ = lt.mgr.peek(ctx, lt.group, k) // 只看缓存：预取没查到的 key 不再逐个单查
+			} else {
+				var err error
+				if v, err = st.translate(ctx, k, ""); err != nil {
+					return err
+				}
+			}
+			if v, _, _ = splitDisabled(v); v != "" {
+				next = append(next, v)
+			}
+		}
+		keys = next
+	}
+	return nil
+}
+
+// dedupeKeys 去重并去掉空 key（原地复用底层数组）
+func dedupeKeys(keys []string) []string {
+	seen := make(map[string]struct{}, len(keys))
+	out := keys[:0]
+	for _, k := range keys {
+		if _, dup := seen[k]; dup || k == "" {
+			continue
+		}
+		seen[k] = struct{}{}
+		out = append(out, k)
+	}
+	return out
+}
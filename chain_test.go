@@ -0,0 +1,114 @@
+package dict
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+)
+
+// 部门表：id -> parent_id / name；d3 的上级指向自己（坏数据）
+type deptDB struct {
+	single, batch int64
+}
+
+var deptRows = map[string]map[string]string{
+	"d1": {"parent_id": "", "name": "总公司"},
+	"d2": {"parent_id": "d1", "name": "研发部"},
+	"d3": {"parent_id": "d3", "name": "孤岛"},
+	"d4": {"parent_id": "d2", "name": "后端组"},
+}
+
+func (d *deptDB) Query(_, _, valueField string, key any) (string, error) {
+	atomic.AddInt64(&d.single, 1)
+	return deptRows[key.(string)][valueField], nil
+}
+
+func (d *deptDB) QueryBatch(_ context.Context, _, _, valueField string, keys []string) (map[string]string, error) {
+	atomic.AddInt64(&d.batch, 1)
+	out := map[string]string{}
+	for _, k := range keys {
+		if v := deptRows[k][valueField]; v != "" {
+			out[k] = v
+		}
+	}
+	return out, nil
+}
+
+func TestChainTranslation(t *testing.T) {
+	EnableDBCache(true)
+	ClearDBCache()
+	t.Cleanup(ClearDBCache)
+	be := &deptDB{}
+	RegisterDBTranslator(be)
+
+	type Emp struct {
+		DeptID         string `chain:"db:table=dept,key=id,value=parent_id | db:table=dept,key=id,value=name" dictField:"ParentDeptName"`
+		ParentDeptName string
+	}
+	e := &Emp{DeptID: "d2"}
+	if err := Translate(e); err != nil || e.ParentDeptName != "总公司" {
+		t.Fatalf("链式翻译失败: %v %q", err, e.ParentDeptName)
+	}
+	e = &Emp{DeptID: "d1"}
+	if err := Translate(e); err != nil || e.ParentDeptName != "" {
+		t.Fatalf("没有上级时应为空: %v %q", err, e.ParentDeptName)
+	}
+
+	// 切片：每层一次批量（2 层 = 2 次），不按元素单查
+	ClearDBCache()
+	atomic.StoreInt64(&be.single, 0)
+	emps := make([]Emp, 30)
+	for i := range emps {
+		emps[i].DeptID = []string{"d2", "d4"}[i%2]
+	}
+	if err := Translate(&emps); err != nil {
+		t.Fatal(err)
+	}
+	if emps[0].ParentDeptName != "总公司" || emps[1].ParentDeptName != "研发部" {
+		t.Fatalf("链式批量翻译结果不对: %+v", emps[:2])
+	}
+	if b, s := atomic.LoadInt64(&be.batch), atomic.LoadInt64(&be.single); b != 2 || s != 0 {
+		t.Fatalf("期望每层一次批量（共 2 次）0 次单查，实际 batch=%d single=%d", b, s)
+	}
+}
+
+func TestChainCycleAndLimits(t *testing.T) {
+	EnableDBCache(true)
+	ClearDBCache()
+	t.Cleanup(ClearDBCache)
+	RegisterDBTranslator(&deptDB{})
+
+	type Emp struct {
+		DeptID string `chain:"db:dept:id:parent_id | db:dept:id:parent_id | db:dept:id:name" dictField:"Name"`
+		Name   string
+	}
+	e := &Emp{DeptID: "d3"}
+	if err := Translate(e); err != nil || e.Name != "" {
+		t.Fatalf("成环数据应停止并返回空: %v %q", err, e.Name)
+	}
+	e = &Emp{DeptID: "d4"}
+	if err := Translate(e); err != nil || e.Name != "总公司" {
+		t.Fatalf("三层链: %v %q", err, e.Name)
+	}
+
+	dm := NewDictManager()
+	long := "dict:a"
+	for i := 0; i < maxChainSteps; i++ {
+		long += " | dict:a"
+	}
+	if dm.parseChainTag(long) != nil || dm.parseChainTag("dict:a") != nil || dm.parseChainTag("dict:a | bogus:x") != nil {
+		t.Fatal("超长 / 单步 / 含无效步骤的链应被忽略")
+	}
+
+	// 内存字典与枚举也能作为链上的步骤
+	dm.RegisterDict("alias", map[string]string{"x": "1"})
+	RegisterEnum("chain_status", map[string]string{"1": "启用"})
+	type Row struct {
+		Code  string `chain:"dict:alias | enum:chain_status" dictField:"Label"`
+		Label string
+	}
+	r := &Row{Code: "x"}
+	if err := dm.Translate(r); err != nil || r.Label != "启用" {
+		t.Fatalf("dict → enum 链: %v %q", err, r.Label)
+	}
+}
@@ -30,7 +30,7 @@ type PerformanceConfig struct {
 	// 最大并发数
 	MaxConcurrency int
 
-	// 预加载字典：启动时预加载常用字典到内存
+	// 预加载字典：启动时预加载常用字典到内存；"*" 表示一次查询预加载全部类型（单表后端需实现 DictTableBulkLoader，双表后端实现了也一并加载），可与具名类型并列
 	PreloadDicts []string
 
 	// 连接池配置（数据库相关）
@@ -0,0 +1,142 @@
+package dict
+
+import (
+	"context"
+	"reflect"
+)
+
+// TranslateCopy 不改动入参：深拷贝 v 后翻译副本并返回（缓存里的共享对象可以直接传）。
+// 遍历计划与批量预取同 TranslateWith
+func TranslateCopy[T any](ctx context.Context, v *T) (*T, error) {
+	if v == nil {
+		return nil, ErrNotPointer
+	}
+	cp := deepCopy(reflect.ValueOf(v), make(map[visitKey]reflect.Value)).Interface().(*T)
+	if err := defaultManager.TranslateWith(cp, WithContext(ctx)); err != nil {
+		return nil, err
+	}
+	return cp, nil
+}
+
+// Labels 不改动入参，返回 路径 -> 译文（如 "Items[0].Status" -> "已支付"；map 元素为 "ByID[a].Status"；
+// 多目标字段按目标字段名记）。v 与 Translate 的入参相同；同一指针目标被多处引用时只记第一次到达的路径
+func Labels(ctx context.Context, v any) (map[string]string, error) {
+	return defaultManager.Labels(ctx, v)
+}
+
+// Labels 返回路径 -> 译文（实例方法）
+func (dm *DictManager) Labels(ctx context.Context, v any) (map[string]string, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return nil, ErrNotPointer
+	}
+	_, labels, err := dm.labelsOf(ctx, rv)
+	return labels, err
+}
+
+// labelsOf 深拷贝 rv（指针）并翻译副本，同时按路径记下译文；返回翻译后的副本
+func (dm *DictManager) labelsOf(ctx context.Context, rv reflect.Value) (reflect.Value, map[string]string, error) {
+	labels := make(map[string]string)
+	cp := deepCopy(rv, make(map[visitKey]reflect.Value))
+	if err := dm.TranslateWith(cp.Interface(), WithContext(ctx), func(o *translateOpts) { o.labels = labels }); err != nil {
+		return cp, nil, err
+	}
+	return cp, labels, nil
+}
+
+// deepCopy 深拷贝：指针、切片、map、接口逐层复制，同一指针 / map 只复制一次（共享与成环关系不变）。
+// 未导出字段随结构体整体浅拷贝（反射写不了），chan、func 共享
+func deepCopy(v reflect.Value, seen map[visitKey]reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		key := visitKey{addr: v.Pointer(), typ: v.Type()}
+		if cp, ok := seen[key]; ok {
+			return cp
+		}
+		cp := reflect.New(v.Type().Elem())
+		seen[key] = cp
+		cp.Elem().Set(deepCopy(v.Elem(), seen))
+		return cp
+	case reflect.Struct:
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(v)
+		rt := v.Type()
+		for i := 0; i < rt.NumField(); i++ {
+			if rt.Field(i).IsExported() && needsDeepCopy(rt.Field(i).Type) {
+				cp.Field(i).Set(deepCopy(v.Field(i), seen))
+			}
+		}
+		return cp
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		if !needsDeepCopy(v.Type().Elem()) {
+			reflect.Copy(cp, v)
+			return cp
+		}
+		// 经接口装着自己的切片（[]any）会成环：同一底层数组、同长度的切片只复制一次
+		key := visitKey{addr: v.Pointer(), typ: v.Type(), n: v.Len()}
+		if prev, ok := seen[key]; ok {
+			return prev
+		}
+		seen[key] = cp
+		for i := 0; i < v.Len(); i++ {
+			cp.Index(i).Set(deepCopy(v.Index(i), seen))
+		}
+		return cp
+	case reflect.Array:
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(v)
+		if needsDeepCopy(v.Type().Elem()) {
+			for i := 0; i < v.Len(); i++ {
+				cp.Index(i).Set(deepCopy(v.Index(i), seen))
+			}
+		}
+		return cp
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		key := visitKey{addr: v.Pointer(), typ: v.Type()}
+		if cp, ok := seen[key]; ok {
+			return cp
+		}
+		cp := reflect.MakeMapWithSize(v.Type(), v.Len())
+		seen[key] = cp
+		iter := v.MapRange()
+		for iter.Next() {
+			cp.SetMapIndex(iter.Key(), deepCopy(iter.Value(), seen))
+		}
+		return cp
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(deepCopy(v.Elem(), seen))
+		return cp
+	}
+	return v
+}
+
+// needsDeepCopy 类型里是否有需要逐层复制的引用（指针、切片、map、接口）；纯值类型整体赋值即可
+func needsDeepCopy(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		return true
+	case reflect.Array:
+		return needsDeepCopy(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() && needsDeepCopy(t.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package dict
+
+import (
+	"context"
+	"testing"
+)
+
+type copyNode struct {
+	Sex      string `dict:"sex" dictField:"SexName"`
+	SexName  string
+	Children []*copyNode
+	Parent   *copyNode
+	Extra    map[string]any
+}
+
+func TestTranslateCopy(t *testing.T) {
+	RegisterDict("sex", map[string]string{"1": "男", "2": "女"})
+	root := &copyNode{Sex: "1"}
+	child := &copyNode{Sex: "2", Parent: root}
+	root.Children = []*copyNode{child, child}
+	root.Extra = map[string]any{"n": copyNode{Sex: "2"}}
+
+	cp, err := TranslateCopy(context.Background(), root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root.SexName != "" || child.SexName != "" || root.Extra["n"].(copyNode).SexName != "" {
+		t.Fatalf("入参不应被改动: %+v", root)
+	}
+	if cp.SexName != "男" || cp.Children[0].SexName != "女" || cp.Extra["n"].(copyNode).SexName != "女" {
+		t.Fatalf("副本翻译失败: %+v", cp)
+	}
+	if cp.Children[0] != cp.Children[1] || cp.Children[0].Parent != cp || cp.Children[0] == child {
+		t.Fatalf("副本应保持共享与成环关系且不指向原对象")
+	}
+}
+
+func TestLabels(t *testing.T) {
+	RegisterDict("sex", map[string]string{"1": "男", "2": "女"})
+	type Row struct {
+		Items   []copyNode
+		ByID    map[string]*copyNode
+		Sex     string `dict:"sex" dictField:"SexName"`
+		SexName string
+	}
+	r := &Row{Items: []copyNode{{Sex: "1"}, {Sex: "9"}}, ByID: map[string]*copyNode{"a": {Sex: "2"}}, Sex: "2"}
+	labels, err := Labels(context.Background(), r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"Items[0].Sex": "男", "ByID[a].Sex": "女", "Sex": "女"}
+	if len(labels) != len(want) {
+		t.Fatalf("路径数量不对: %v", labels)
+	}
+	for k, v := range want {
+		if labels[k] != v {
+			t.Fatalf("%s 期望 %q，实际 %q（全部: %v）", k, v, labels[k], labels)
+		}
+	}
+	if r.SexName != "" || r.Items[0].SexName != "" || r.ByID["a"].SexName != "" {
+		t.Fatalf("Labels 不应改动入参: %+v", r)
+	}
+
+	rows := []*copyNode{{Sex: "1"}, {Sex: "2"}}
+	labels, err = Labels(context.Background(), &rows)
+	if err != nil || labels["[0].Sex"] != "男" || labels["[1].Sex"] != "女" {
+		t.Fatalf("切片路径不对: %v %v", err, labels)
+	}
+	if _, err := Labels(context.Background(), *r); err != ErrNotPointer {
+		t.Fatalf("非指针应返回 ErrNotPointer: %v", err)
+	}
+}
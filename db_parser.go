@@ -4,42 +4,77 @@ import (
 	"strings"
 )
 
+// dbSpec db 标签解析结果
+type dbSpec struct {
+	table, keyField, valueField string
+	from                        []string // from=A+B：组成复合键的源字段名，与 key=a+b 的列一一对应
+}
+
+// targetsMatch 多列（value=a+b）时 dictField 的目标数须与列数一致；单列不限
+func (spec dbSpec) targetsMatch(dictField string) bool {
+	cols := strings.Count(spec.valueField, "+") + 1
+	if cols == 1 {
+		return true
+	}
+	return dictField != "" && strings.Count(dictField, ",")+1 == cols
+}
+
 // parseDBTag 解析数据库翻译标签
 // 格式: db:"table=user,key=id,value=name"
 // 或: db:"user:id:name" (简化格式)
+// 复合键: db:"table=t,key=org_id+code,value=name,from=OrgID+Code"
 func parseDBTag(tag string) Translator {
-	if tag == "" {
+	spec, ok := parseDBSpec(tag)
+	if !ok {
 		return nil
 	}
+	return createDBTranslator(spec.table, spec.keyField, spec.valueField)
+}
 
-	var table, keyField, valueField string
+// parseDBSpec 解析 db 标签；三段不齐，或复合键缺 from / 列数与 from 字段数不一致时返回 false
+func parseDBSpec(tag string) (dbSpec, bool) {
+	var spec dbSpec
+	if tag == "" {
+		return spec, false
+	}
 
 	// 检查是否是简化格式 "table:key:value"
 	if strings.Contains(tag, ":") && !strings.Contains(tag, "=") {
 		parts := strings.Split(tag, ":")
 		if len(parts) == 3 {
-			table = strings.TrimSpace(parts[0])
-			keyField = strings.TrimSpace(parts[1])
-			valueField = strings.TrimSpace(parts[2])
+			spec.table = strings.TrimSpace(parts[0])
+			spec.keyField = strings.TrimSpace(parts[1])
+			spec.valueField = strings.TrimSpace(parts[2])
 		}
 	} else {
-		// 解析完整格式 "table=user,key=id,value=name"
+		// 解析完整格式 "table=user,key=id,value=name[,from=A+B]"
 		parts := strings.Split(tag, ",")
 		for _, part := range parts {
 			part = strings.TrimSpace(part)
 			if strings.HasPrefix(part, "table=") {
-				table = strings.TrimPrefix(part, "table=")
+				spec.table = strings.TrimPrefix(part, "table=")
 			} else if strings.HasPrefix(part, "key=") {
-				keyField = strings.TrimPrefix(part, "key=")
+				spec.keyField = strings.TrimPrefix(part, "key=")
 			} else if strings.HasPrefix(part, "value=") {
-				valueField = strings.TrimPrefix(part, "value=")
+				spec.valueField = strings.TrimPrefix(part, "value=")
+			} else if strings.HasPrefix(part, "from=") {
+				spec.from = strings.Split(strings.TrimPrefix(part, "from="), "+")
 			}
 		}
 	}
 
-	if table == "" || keyField == "" || valueField == "" {
-		return nil
+	if spec.table == "" || spec.keyField == "" || spec.valueField == "" {
+		return spec, false
 	}
-
-	return createDBTranslator(table, keyField, valueField)
+	if keys := strings.Split(spec.keyField, "+"); len(keys) > 1 || spec.from != nil {
+		if len(keys) != len(spec.from) {
+			return spec, false
+		}
+		for i := range keys {
+			if keys[i] == "" || spec.from[i] == "" {
+				return spec, false
+			}
+		}
+	}
+	return spec, true
 }
@@ -1,6 +1,11 @@
 package dict
 
 import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
 )
 
@@ -159,3 +164,155 @@ func TestDBCache(t *testing.T) {
 		t.Errorf("Expected 2 queries (cache disabled), got %d", queryCount)
 	}
 }
+
+// 复合键后端：按 org_id + code 两列查
+type compositeDB struct {
+	single, batch int64
+	data          map[string]string // CompositeKey(org, code) -> name
+}
+
+func (c *compositeDB) Query(string, string, string, any) (string, error) { return "", nil }
+func (c *compositeDB) QueryComposite(_ context.Context, table string, keyFields []string, valueField string, key []string) (string, error) {
+	atomic.AddInt64(&c.single, 1)
+	if table != "org_item" || strings.Join(keyFields, ",") != "org_id,code" || valueField != "name" {
+		return "", fmt.Errorf("unexpected query %s %v %s", table, keyFields, valueField)
+	}
+	return c.data[CompositeKey(key...)], nil
+}
+func (c *compositeDB) QueryCompositeBatch(_ context.Context, _ string, _ []string, _ string, keys [][]string) (map[string]string, error) {
+	atomic.AddInt64(&c.batch, 1)
+	out := map[string]string{}
+	for _, k := range keys {
+		if v, ok := c.data[CompositeKey(k...)]; ok {
+			out[CompositeKey(k...)] = v
+		}
+	}
+	return out, nil
+}
+
+func TestDBCompositeKey(t *testing.T) {
+	EnableDBCache(true)
+	ClearDBCache()
+	t.Cleanup(ClearDBCache)
+	be := &compositeDB{data: map[string]string{
+		CompositeKey("1", "A"): "总部-A",
+		CompositeKey("2", "A"): "分部-A",
+	}}
+	RegisterDBTranslator(be)
+
+	type Item struct {
+		OrgID    int64
+		Code     string `db:"table=org_item,key=org_id+code,value=name,from=OrgID+Code" dictField:"CodeName"`
+		CodeName string
+	}
+	it := &Item{OrgID: 2, Code: "A"}
+	if err := Translate(it); err != nil || it.CodeName != "分部-A" {
+		t.Fatalf("复合键翻译失败: %v %q", err, it.CodeName)
+	}
+
+	items := make([]Item, 20)
+	for i := range items {
+		items[i] = Item{OrgID: int64(1 + i%2), Code: "A"}
+	}
+	ClearDBCache()
+	if err := Translate(&items); err != nil {
+		t.Fatal(err)
+	}
+	if items[0].CodeName != "总部-A" || items[1].CodeName != "分部-A" {
+		t.Fatalf("批量复合键翻译失败: %+v %+v", items[0], items[1])
+	}
+	if b, s := atomic.LoadInt64(&be.batch), atomic.LoadInt64(&be.single); b != 1 || s != 1 {
+		t.Fatalf("期望 1 次批量（外加前面的 1 次单查），实际 batch=%d single=%d", b, s)
+	}
+
+	// 复合键列数与 from 字段数不一致 → 标签忽略
+	if _, ok := parseDBSpec("table=t,key=a+b,value=v,from=A"); ok {
+		t.Fatal("列数不一致的复合键应解析失败")
+	}
+}
+
+// 多列取值后端：一行取 name + avatar
+type rowDB struct {
+	single, batch int64
+	rows          map[string]map[string]string
+}
+
+func (r *rowDB) Query(string, string, string, any) (string, error) { return "", nil }
+func (r *rowDB) QueryRow(_ context.Context, _ string, _, valueFields []string, key []string) (map[string]string, error) {
+	atomic.AddInt64(&r.single, 1)
+	if strings.Join(valueFields, ",") != "name,avatar" {
+		return nil, fmt.Errorf("unexpected columns %v", valueFields)
+	}
+	return r.rows[key[0]], nil
+}
+func (r *rowDB) QueryRowBatch(_ context.Context, _ string, _, _ []string, keys [][]string) (map[string]map[string]string, error) {
+	atomic.AddInt64(&r.batch, 1)
+	out := map[string]map[string]string{}
+	for _, k := range keys {
+		if row, ok := r.rows[k[0]]; ok {
+			out[CompositeKey(k...)] = row
+		}
+	}
+	return out, nil
+}
+
+func TestDBMultipleValueColumns(t *testing.T) {
+	EnableDBCache(true)
+	ClearDBCache()
+	t.Cleanup(ClearDBCache)
+	be := &rowDB{rows: map[string]map[string]string{
+		"1": {"name": "张三", "avatar": "a1.png"},
+		"2": {"name": "李四", "avatar": ""},
+	}}
+	RegisterDBTranslator(be)
+
+	type Post struct {
+		UserID     string `db:"table=user,key=id,value=name+avatar" dictField:"UserName,UserAvatar"`
+		UserName   string
+		UserAvatar string
+	}
+	posts := make([]Post, 20)
+	for i := range posts {
+		posts[i].UserID = "12"[i%2 : i%2+1]
+	}
+	if err := Translate(&posts); err != nil {
+		t.Fatal(err)
+	}
+	if posts[0].UserName != "张三" || posts[0].UserAvatar != "a1.png" || posts[1].UserName != "李四" || posts[1].UserAvatar != "" {
+		t.Fatalf("多列取值不对: %+v %+v", posts[0], posts[1])
+	}
+	if b, s := atomic.LoadInt64(&be.batch), atomic.LoadInt64(&be.single); b != 1 || s != 0 {
+		t.Fatalf("期望一次 IN 查询取全部列，实际 batch=%d single=%d", b, s)
+	}
+
+	p := &Post{UserID: "1"}
+	ClearDBCache()
+	if err := Translate(p); err != nil || p.UserName != "张三" || p.UserAvatar != "a1.png" {
+		t.Fatalf("单条多列取值失败: %v %+v", err, p)
+	}
+}
+
+// 列数与目标数不一致：标签忽略，不把编码后的整行写进目标；映射登记时报错
+func TestDBMultipleValueColumnsMismatch(t *testing.T) {
+	EnableDBCache(true)
+	ClearDBCache()
+	t.Cleanup(ClearDBCache)
+	RegisterDBTranslator(&rowDB{rows: map[string]map[string]string{"1": {"name": "张三", "avatar": "a1.png"}}})
+
+	type Post struct {
+		UserID   string `db:"table=user,key=id,value=name+avatar" dictField:"UserName"`
+		UserName string
+		Other    string `db:"table=user,key=id,value=name+avatar"`
+	}
+	p := &Post{UserID: "1", Other: "1"}
+	if err := Translate(p); err != nil || p.UserName != "" {
+		t.Fatalf("列数与目标数不一致时标签应忽略: %v %+v", err, p)
+	}
+	if labels, err := Labels(context.Background(), p); err != nil || len(labels) != 0 {
+		t.Fatalf("不应产生编码后的整行标签: %v %v", err, labels)
+	}
+	err := RegisterTypeMapping(reflect.TypeOf(Post{}), []FieldMapping{{Field: "Other", Source: "db", Value: "table=user,key=id,value=name+avatar", Target: "UserName"}})
+	if err == nil {
+		t.Fatalf("映射的列数与目标数不一致应报错")
+	}
+}
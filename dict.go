@@ -8,8 +8,8 @@
 // the sibling field StatusName. Sources are in-memory dictionaries (RegisterDict),
 // enums (RegisterEnum), database dictionary tables (RegisterDictTableTranslator,
 // RegisterDictTableTwoTranslator, RegisterDBTranslator) or custom translators
-// (RegisterTranslator). Translate walks nested structs, pointers and slices;
-// BatchTranslate adds a parallel worker pool for large slices.
+// (RegisterTranslator). Translate walks nested structs, pointers, slices, arrays,
+// map values and interface (any) fields, following their dynamic types; BatchTranslate adds a parallel worker pool for large slices.
 //
 // TranslateWith adds functional options: WithContext for cancellation (passed to
 // translators implementing ContextTranslator), WithParallel for a worker pool and
@@ -28,9 +28,11 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 // DictManager 字典管理器
@@ -40,15 +42,23 @@ type DictManager struct {
 	unwrappers  []UnWrapper                    // 包装类型解包器
 	configCache map[reflect.Type]*structConfig // 配置缓存
 	configMutex sync.RWMutex                   // 配置缓存互斥锁
+
+	selections    sync.Map                            // WithFields / WithScene 解析结果：selectionKey -> *fieldSelection
+	selectionN    atomic.Int32                        // selections 的条数（上限 maxSelections）
+	selectedCache map[selectedConfigKey]*structConfig // 按选择过滤后的遍历计划（configMutex 保护）
+	intoCache     map[intoKey]*intoPlan               // TranslateInto 按（源类型, 目标类型）编译的计划（configMutex 保护）
+	enumGen       atomic.Uint64                       // 配置缓存对应的 typedEnums.gen，落后时先清缓存
 }
 
 // registry 字典与自定义翻译器注册表。读多写少（注册在启动期、翻译在热路径），
 // 用 atomic.Pointer 做写时复制：读是一次原子 Load，不与其他 goroutine 争锁；
 // 写在 regWrite 下拷贝一份再 Store。
-// ponytail: 每次注册全量拷贝两张小 map，注册次数少可接受。
+// ponytail: 每次注册全量拷贝几张小 map，注册次数少可接受。
 type registry struct {
-	dicts       map[string]map[string]string // dictName -> {key: value}
-	translators map[string]Translator        // tagName -> Translator
+	dicts       map[string]map[string]string             // dictName -> {key: value}
+	translators map[string]Translator                    // tagName -> Translator
+	trees       map[string]map[string]TreeNode           // treeName -> {key: 节点}
+	mappings    map[reflect.Type]map[string]FieldMapping // RegisterTypeMapping：类型 -> 字段名 -> 映射
 }
 
 var emptyRegistry = &registry{}
@@ -69,6 +79,8 @@ func (dm *DictManager) updateReg(fn func(*registry)) {
 	next := &registry{
 		dicts:       make(map[string]map[string]string, len(old.dicts)+1),
 		translators: make(map[string]Translator, len(old.translators)+1),
+		trees:       make(map[string]map[string]TreeNode, len(old.trees)),
+		mappings:    make(map[reflect.Type]map[string]FieldMapping, len(old.mappings)),
 	}
 	for k, v := range old.dicts {
 		next.dicts[k] = v
@@ -76,6 +88,12 @@ func (dm *DictManager) updateReg(fn func(*registry)) {
 	for k, v := range old.translators {
 		next.translators[k] = v
 	}
+	for k, v := range old.trees {
+		next.trees[k] = v
+	}
+	for k, v := range old.mappings {
+		next.mappings[k] = v
+	}
 	fn(next)
 	dm.reg.Store(next)
 }
@@ -92,22 +110,63 @@ func NewDictManager() *DictManager {
 
 // walk 一次翻译遍历的私有状态（不放在 DictManager 上）：
 //   - ctx：TranslateWith(WithContext) 传入，进结构体时检查取消，并传给实现了 ContextTranslator 的翻译器
-//   - collect：非 nil 表示"收集模式"——只收集 DB 类翻译器要查的 key，不翻译；用于批量前一次 IN 查询预热缓存
+//   - collect：非 nil 表示"收集模式"——只收集 DB 类翻译器要查的 key，不翻译；用于批量前一次 IN 查询预热缓存。
+//     按（翻译器, 缓存作用域）分组：同一字典不同租户 / 不同生效版本各查一次
 //   - visited 集：记录已进入过的指针目标，防止自引用/环形结构无限递归。
 //     只有经指针到达的结构体才需要记录（值类型嵌套不可能成环），map 惰性分配，无指针的常见场景零开销。
 //     以 (地址, 类型) 为键：外层结构体与其第一个字段地址相同，只用地址会误判。
 type walk struct {
 	ctx     context.Context
-	collect map[*lookupTranslator][]string
-	mu      *sync.Mutex // 并行批量时多个 worker 共享一份 walk，用它保护 visited 集；顺序翻译为 nil
-	small   [4]visitKey // 前几个指针目标放栈上，常见 DTO 不碰堆
+	collect map[collectKey]*collectGroup
+	batched map[batchKey]*collectGroup // 收集模式下 chain / tree 等自带预取逻辑的字段的源 key
+	mu      *sync.Mutex                // 并行批量时多个 worker 共享一份 walk，用它保护 visited 集；顺序翻译为 nil
+	small   [4]visitKey                // 前几个指针目标放栈上，常见 DTO 不碰堆
 	n       int
 	m       map[visitKey]struct{} // 溢出后才分配
+	labels  map[string]string     // Labels：路径 -> 译文；nil 时不记路径，热路径只多一次判空
+	sel     *fieldSelection       // WithFields / WithScene：只读，并行 worker 共享
+	overlay *overlay              // WithDict / WithTranslator：只读
+	path    []string              // 当前路径的各段（".Items"、"[0]"），只在 labels 非 nil 时维护
+}
+
+func (w *walk) enter(seg string) { w.path = append(w.path, seg) }
+func (w *walk) leave()           { w.path = w.path[:len(w.path)-1] }
+
+// record 记下当前结构体里某个字段的译文，键形如 Items[0].Status
+func (w *walk) record(name, label string) {
+	w.labels[strings.TrimPrefix(strings.Join(w.path, "")+"."+name, ".")] = label
+}
+
+// collectKey 收集模式的分组：翻译器 + 缓存作用域（CacheScoper 在该 ctx 下的取值）
+type collectKey struct {
+	lt    *lookupTranslator
+	scope string
+}
+
+// prefetcher 自带预取逻辑的翻译器（chain 按层、tree 按祖先层级批量）：收集模式按（翻译器, 作用域）攒 key，
+// 预取时整组交给它
+type prefetcher interface {
+	Translator
+	scope(ctx context.Context) string
+	prefetch(ctx context.Context, keys []string) error
+}
+
+// batchKey prefetcher 字段在收集模式的分组
+type batchKey struct {
+	p     prefetcher
+	scope string
+}
+
+// collectGroup 一组要预取的 key，ctx 取组内任一字段的（作用域相同，查询结果相同）
+type collectGroup struct {
+	ctx  context.Context
+	keys []string
 }
 
 type visitKey struct {
 	addr uintptr
 	typ  reflect.Type
+	n    int // 切片长度：同一底层数组的不同子切片（top N + 全量）不算同一个
 }
 
 // mark 记录一个指针目标；已记录过返回 false（调用方跳过）。
@@ -115,11 +174,23 @@ func (seen *walk) mark(rv reflect.Value) bool {
 	if !rv.CanAddr() {
 		return true
 	}
+	return seen.markKey(visitKey{addr: rv.UnsafeAddr(), typ: rv.Type()})
+}
+
+// markRef 记录一个 map / 切片（按底层数据指针，切片再加长度）；已记录过返回 false
+func (seen *walk) markRef(rv reflect.Value) bool {
+	key := visitKey{addr: rv.Pointer(), typ: rv.Type()}
+	if rv.Kind() == reflect.Slice {
+		key.n = rv.Len()
+	}
+	return seen.markKey(key)
+}
+
+func (seen *walk) markKey(key visitKey) bool {
 	if seen.mu != nil {
 		seen.mu.Lock()
 		defer seen.mu.Unlock()
 	}
-	key := visitKey{addr: rv.UnsafeAddr(), typ: rv.Type()}
 	for i := 0; i < seen.n; i++ {
 		if seen.small[i] == key {
 			return false
@@ -148,7 +219,13 @@ type structConfig struct {
 	byIndex   []*fieldConfig // 按字段下标预建的索引，长度 = NumField，无配置处为 nil
 	steps     []fieldStep    // 翻译时真正要看的字段（嵌套 或 带翻译标签），普通字段不进循环
 	noop      bool           // 没有任何可设置字段（如 time.Time），翻译时直接跳过、不记 visited
-	mayLookup bool           // 自身有 DB 类翻译器，或有嵌套 struct/ptr/slice 字段（可能藏着）——决定批量前要不要走收集遍历
+	mayLookup bool           // 自身有 DB 类翻译器，或有嵌套 struct/ptr/slice/map/array/any 字段（可能藏着）——决定批量前要不要走收集遍历
+	translate bool           // 有 translate 标签字段（WithTranslator 可能换成 DB 类翻译器）
+}
+
+// lookupWith 带上本次调用的覆盖后是否可能查库
+func (c *structConfig) lookupWith(ov *overlay) bool {
+	return c.mayLookup || (ov != nil && ov.lookup && c.translate)
 }
 
 // fieldStep 一个字段在翻译遍历中的预算好的动作：nested 表示要递归进去（struct / *struct / slice），cfg 表示要翻译
@@ -165,6 +242,7 @@ const (
 	nestedStruct
 	nestedPtr
 	nestedSlice
+	nestedAny // map / 数组 / 空接口 / 其他可能藏着结构体的容器，走 translateNested
 )
 
 // fieldConfig 字段配置（getOrCreateConfig 时一次算好，翻译热路径零解析）
@@ -174,8 +252,19 @@ type fieldConfig struct {
 	targetField      string
 	targetFieldIndex int    // 缓存目标字段索引，避免每次查找
 	translatorTag    string // 原始 tag（传给翻译器）
+	translateName    string // translate 标签的翻译器名（未注册时 translator 为空，WithTranslator 可按名覆盖）
 	dictName         string // dict 标签：逗号前的字典名，预先拆好
 	translator       Translator
+	selector         *fieldSelector // 多态字典（@Field / switch）：按兄弟字段的值逐元素选来源，非 nil 时 translator 为空
+
+	disabledFieldIndex int    // 标签选项 disabledField= 指定的 bool 字段：后端标记停用（MarkDisabled）时置 true；-1 表示未配置
+	asOfIndex          int    // 标签选项 asOf= 指定的日期字段（time.Time / *time.Time）：按它取生效版本；-1 表示未配置
+	fromIndexes        []int  // db 复合键 from=A+B 的源字段下标：非空时源值是这些字段拼成的 CompositeKey
+	targetIndexes      []int  // dictField:"A,B" 多目标：翻译结果按 CompositeKey 拆开依次写入；找不到的目标为 -1
+	normalize          bool   // 源字段要先归一化（指针、sql.Null*、Valuer、TextMarshaler，见 sourceKey）
+	clearOnMiss        bool   // 标签选项 miss=nil：没有翻译结果时把目标字段置零（*string 为 nil、sql.NullString 无效）
+	rawSource          bool   // 源值用字段原始值（不先转成 int64 / string），DictLabeler 需要原类型
+	flagSep            string // enum 标签的 flags 选项：非空表示位掩码枚举，string 目标用它连接各位标签
 }
 
 // RegisterDict 注册字典
@@ -212,9 +301,15 @@ func (dm *DictManager) GetDict(name string) map[string]string {
 // 结构体配置缓存里固化了翻译器实例，注册后清空缓存，保证已翻译过的类型也能拿到新翻译器。
 func (dm *DictManager) RegisterTranslator(tagName string, translator Translator) {
 	dm.updateReg(func(r *registry) { r.translators[tagName] = translator })
+	dm.resetConfigs()
+}
 
+// resetConfigs 清空所有固化了翻译器的缓存：结构体配置、按选择过滤的计划、TranslateInto 的计划
+func (dm *DictManager) resetConfigs() {
 	dm.configMutex.Lock()
 	dm.configCache = make(map[reflect.Type]*structConfig)
+	dm.selectedCache = nil
+	dm.intoCache = nil
 	dm.configMutex.Unlock()
 }
 
@@ -230,6 +325,23 @@ type translateOpts struct {
 	ctx        context.Context
 	parallel   bool
 	noPrefetch bool
+	labels     map[string]string // Labels：非 nil 时按路径记录每个字段的译文（此时不并行）
+	fields     []string          // WithFields
+	scene      string            // WithScene
+	sel        *fieldSelection   // fields / scene 按根类型解析后的结果
+	overlay    *overlay          // WithDict / WithTranslator
+}
+
+// walk 按选项建一次翻译遍历的状态
+func (o *translateOpts) walk() *walk {
+	return &walk{ctx: o.ctx, labels: o.labels, sel: o.sel, overlay: o.overlay}
+}
+
+// collectWalk 按选项建一次收集遍历的状态（未选中的字段不预取）
+func (o *translateOpts) collectWalk() *walk {
+	w := newCollectWalk(o.ctx)
+	w.sel, w.overlay = o.sel, o.overlay
+	return w
 }
 
 // WithContext 传入 ctx：进每个结构体前检查取消；实现了 ContextTranslator 的翻译器（含内置 DB 类）会收到它
@@ -250,7 +362,7 @@ func buildOpts(opts []Option) translateOpts {
 	return o
 }
 
-// TranslateWith 带选项翻译：v 是 *Struct 或 *[]Struct / *[]*Struct（包装类型会先解包）
+// TranslateWith 带选项翻译：v 是 *Struct、*[]Struct / *[]*Struct，或装着结构体的 *map / *数组 / *any（包装类型会先解包）
 func TranslateWith(v any, opts ...Option) error {
 	return defaultManager.TranslateWith(v, opts...)
 }
@@ -260,11 +372,25 @@ func (dm *DictManager) TranslateWith(v any, opts ...Option) error {
 	var o translateOpts
 	if len(opts) > 0 {
 		o = buildOpts(opts) // 只有真传了选项才有一次堆分配，Translate(v) 零开销
+		if o.overlay != nil {
+			o.ctx = withOverlay(o.ctx, o.overlay)
+		}
 	}
 
 	// 尝试解包包装类型
 	if unwrapped, ok := dm.tryUnwrap(v); ok {
-		v = unwrapped
+		switch rv := reflect.ValueOf(unwrapped); rv.Kind() {
+		case reflect.Slice, reflect.Map:
+			// 解包器直接返回了切片 / map（如 Result.Data）：与原值共享底层数据，套一层指针原地翻译
+			p := reflect.New(rv.Type())
+			p.Elem().Set(rv)
+			v = p.Interface()
+		case reflect.Struct:
+			// 返回的是结构体值（副本），翻译它改不到包装上：改为遍历包装本身，
+			// any / map 里的结构体值由嵌套遍历拷贝出来翻译再写回
+		default:
+			v = unwrapped
+		}
 	}
 
 	rv := reflect.ValueOf(v)
@@ -273,13 +399,31 @@ func (dm *DictManager) TranslateWith(v any, opts ...Option) error {
 	}
 	rv = rv.Elem()
 
-	if rv.Kind() == reflect.Slice {
-		return dm.translateSliceOpts(rv, &o)
+	if len(o.fields) > 0 || o.scene != "" {
+		root := rv.Type()
+		if rv.Kind() == reflect.Interface && !rv.IsNil() {
+			root = rv.Elem().Type()
+		}
+		sel, err := dm.selection(root, &o)
+		if err != nil {
+			return err
+		}
+		o.sel = sel
 	}
-	if rv.Kind() != reflect.Struct {
+
+	switch rv.Kind() {
+	case reflect.Slice:
+		if nestedKindOf(rv.Type()) == nestedSlice {
+			return dm.translateSliceOpts(rv, &o)
+		}
+		return dm.translateContainerOpts(rv, &o)
+	case reflect.Map, reflect.Array, reflect.Interface:
+		return dm.translateContainerOpts(rv, &o)
+	case reflect.Struct:
+	default:
 		return ErrNotStruct
 	}
-	return dm.translateStructV(rv, &walk{ctx: o.ctx}, false)
+	return dm.translateStructV(rv, o.walk(), false)
 }
 
 // translateSliceOpts 顶层切片：预取 → 并行 / 顺序
@@ -287,25 +431,30 @@ func (dm *DictManager) translateSliceOpts(sliceValue reflect.Value, o *translate
 	if err := dm.prefetchSlice(sliceValue, o); err != nil {
 		return err
 	}
-	if o.parallel && sliceValue.Len() >= 10 {
-		return dm.batchTranslateParallel(sliceValue, o.ctx)
+	if o.parallel && o.labels == nil && sliceValue.Len() >= 10 {
+		return dm.batchTranslateParallel(sliceValue, o)
 	}
-	return dm.translateSlice(sliceValue, o.ctx)
+	return dm.translateSlice(sliceValue, o.walk())
 }
 
 // translateSlice 翻译顶层切片：整个切片共享一份 visited。
 // 顶层元素本身不记 visited（平铺 []*Row 零开销），只记从元素内部经指针到达的目标，
 // 所以父子链 / 树形数据里被多个元素共享的子图只走一次，总体 O(n) 而不是 O(n²)。
-func (dm *DictManager) translateSlice(sliceValue reflect.Value, ctx context.Context) error {
-	w := &walk{ctx: ctx}
+func (dm *DictManager) translateSlice(sliceValue reflect.Value, w *walk) error {
 	for i := 0; i < sliceValue.Len(); i++ {
 		elem, ok := sliceElemStruct(sliceValue.Index(i))
 		if !ok {
 			continue
 		}
+		if w.labels != nil {
+			w.enter("[" + strconv.Itoa(i) + "]")
+		}
 		if err := dm.translateStructV(elem, w, false); err != nil {
 			return err
 		}
+		if w.labels != nil {
+			w.leave()
+		}
 	}
 	return nil
 }
@@ -321,11 +470,18 @@ func (dm *DictManager) prefetchSlice(sliceValue reflect.Value, o *translateOpts)
 	if elemType.Kind() == reflect.Ptr {
 		elemType = elemType.Elem()
 	}
-	if elemType.Kind() != reflect.Struct || !dm.getOrCreateConfig(elemType).mayLookup {
+	if elemType.Kind() != reflect.Struct {
+		return nil
+	}
+	config := dm.getOrCreateConfig(elemType)
+	if o.sel != nil {
+		config = dm.selectedConfig(elemType, config, o.sel)
+	}
+	if !config.lookupWith(o.overlay) {
 		return nil
 	}
 
-	w := &walk{ctx: o.ctx, collect: make(map[*lookupTranslator][]string)}
+	w := o.collectWalk()
 	for i := 0; i < n; i++ {
 		elem, ok := sliceElemStruct(sliceValue.Index(i))
 		if !ok {
@@ -335,18 +491,78 @@ func (dm *DictManager) prefetchSlice(sliceValue reflect.Value, o *translateOpts)
 			return err
 		}
 	}
-	ctx := o.ctx
+	return w.flush()
+}
+
+// newCollectWalk 收集模式的遍历状态
+func newCollectWalk(ctx context.Context) *walk {
+	return &walk{ctx: ctx, collect: make(map[collectKey]*collectGroup), batched: make(map[batchKey]*collectGroup)}
+}
+
+// add 收集模式记下一个要查的 key：DB 类翻译器按（翻译器, 作用域）分组，chain / tree 等交给自身预取；
+// 其他翻译器无需预取，忽略
+func (w *walk) add(ctx context.Context, translator Translator, sourceValue any) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	for lt, keys := range w.collect {
-		if err := lt.mgr.prefetch(ctx, lt.group, lt.parts, keys); err != nil {
+	var g *collectGroup
+	if lt, ok := translator.(*lookupTranslator); ok {
+		ck := collectKey{lt: lt, scope: lt.mgr.scope(ctx)}
+		if g = w.collect[ck]; g == nil {
+			g = &collectGroup{ctx: ctx}
+			w.collect[ck] = g
+		}
+	} else if p, ok := translator.(prefetcher); ok {
+		bk := batchKey{p: p, scope: p.scope(ctx)}
+		if g = w.batched[bk]; g == nil {
+			g = &collectGroup{ctx: ctx}
+			w.batched[bk] = g
+		}
+	} else {
+		return
+	}
+	g.keys = append(g.keys, fmt.Sprintf("%v", sourceValue))
+}
+
+// flush 收集遍历结束后按分组批量预取
+func (w *walk) flush() error {
+	for ck, g := range w.collect {
+		if err := ck.lt.mgr.prefetch(g.ctx, ck.lt.group, ck.lt.parts, g.keys); err != nil {
+			return err
+		}
+	}
+	// chain / tree 字段：交给翻译器自己按层批量
+	for bk, g := range w.batched {
+		if err := bk.p.prefetch(g.ctx, g.keys); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// translateContainerOpts 顶层 map / 数组 / 空接口 / []any：元素数够阈值时先走一遍收集预取，再翻译
+func (dm *DictManager) translateContainerOpts(rv reflect.Value, o *translateOpts) error {
+	n := 0
+	switch rv.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		n = rv.Len()
+	case reflect.Interface:
+		if e := rv.Elem(); e.Kind() == reflect.Map || e.Kind() == reflect.Slice {
+			n = e.Len()
+		}
+	}
+	if !o.noPrefetch && n > 0 && n >= GetConfig().Performance.BatchQueryThreshold {
+		w := o.collectWalk()
+		if err := dm.translateNested(rv, w); err != nil {
+			return err
+		}
+		if err := w.flush(); err != nil {
+			return err
+		}
+	}
+	return dm.translateNested(rv, o.walk())
+}
+
 // sliceElemStruct 取切片元素指向的结构体（解一层指针，nil 跳过）
 func sliceElemStruct(elem reflect.Value) (reflect.Value, bool) {
 	if elem.Kind() == reflect.Ptr {
@@ -365,6 +581,9 @@ func (dm *DictManager) translateStructV(rv reflect.Value, seen *walk, viaPtr boo
 
 	// 获取或创建配置缓存（含按字段下标预建的索引）
 	config := dm.getOrCreateConfig(rt)
+	if seen.sel != nil {
+		config = dm.selectedConfig(rt, config, seen.sel)
+	}
 	if config.noop {
 		return nil
 	}
@@ -383,6 +602,9 @@ func (dm *DictManager) translateStructV(rv reflect.Value, seen *walk, viaPtr boo
 		field := rv.Field(st.index)
 
 		// 先处理嵌套，再处理当前字段的翻译
+		if seen.labels != nil && st.nested != nestedNone {
+			seen.enter("." + rt.Field(st.index).Name)
+		}
 		switch st.nested {
 		case nestedStruct:
 			if err := dm.translateStructV(field, seen, false); err != nil {
@@ -399,17 +621,24 @@ func (dm *DictManager) translateStructV(rv reflect.Value, seen *walk, viaPtr boo
 			if err := dm.translateSliceV(field, seen); err != nil {
 				return err
 			}
+		case nestedAny:
+			if err := dm.translateNested(field, seen); err != nil {
+				return err
+			}
+		}
+		if seen.labels != nil && st.nested != nestedNone {
+			seen.leave()
 		}
 
 		// 处理翻译标签（dict, enum, translator等）
 		if fieldCfg := st.cfg; fieldCfg != nil {
-			if fieldCfg.translator != nil {
+			if fieldCfg.translator != nil || fieldCfg.selector != nil || (fieldCfg.translateName != "" && seen.overlay != nil) {
 				if err := dm.translateFieldWithTranslator(field, fieldCfg, rv, seen); err != nil {
 					return err
 				}
 			} else if fieldCfg.dictName != "" && seen.collect == nil {
 				// 兼容旧的 dict 标签（内存字典，收集模式下无事可做）
-				if err := dm.translateField(field, fieldCfg, rv); err != nil {
+				if err := dm.translateField(field, fieldCfg, rv, seen); err != nil {
 					return err
 				}
 			}
@@ -421,6 +650,11 @@ func (dm *DictManager) translateStructV(rv reflect.Value, seen *walk, viaPtr boo
 
 // getOrCreateConfig 获取或创建配置缓存（线程安全）
 func (dm *DictManager) getOrCreateConfig(rt reflect.Type) *structConfig {
+	// RegisterEnumOf 之后，按旧枚举表算的配置作废
+	if g := typedEnums.gen.Load(); dm.enumGen.Load() != g {
+		dm.resetConfigs()
+		dm.enumGen.Store(g)
+	}
 	// 先尝试读锁获取
 	dm.configMutex.RLock()
 	if config, ok := dm.configCache[rt]; ok {
@@ -442,8 +676,12 @@ func (dm *DictManager) getOrCreateConfig(rt reflect.Type) *structConfig {
 		fields: make([]fieldConfig, 0),
 	}
 
+	mappings := dm.loadReg().mappings[rt]
 	for i := 0; i < rt.NumField(); i++ {
 		fieldType := rt.Field(i)
+		if m, ok := mappings[fieldType.Name]; ok {
+			fieldType.Tag = m.tag() // 代码登记的映射覆盖字段上的翻译标签
+		}
 
 		// 检查各种翻译标签
 		dictTag := fieldType.Tag.Get("dict")
@@ -451,47 +689,96 @@ func (dm *DictManager) getOrCreateConfig(rt reflect.Type) *structConfig {
 		dictTableTwoTag := fieldType.Tag.Get("dictTableTwo")
 		enumTag := fieldType.Tag.Get("enum")
 		translateTag := fieldType.Tag.Get("translate")
+		chainTag := fieldType.Tag.Get("chain")
+		treeTag := fieldType.Tag.Get("tree")
 		dbTag := fieldType.Tag.Get("db")
+		switchTag := fieldType.Tag.Get("switch")
+		rangeTag := fieldType.Tag.Get("range")
 		dictFieldTag := fieldType.Tag.Get("dictField")
 
 		fieldCfg := fieldConfig{
-			fieldIndex:       i,
-			fieldName:        fieldType.Name,
-			targetField:      dictFieldTag,
-			targetFieldIndex: -1, // 初始化为 -1，表示未找到
+			fieldIndex:         i,
+			fieldName:          fieldType.Name,
+			targetField:        dictFieldTag,
+			targetFieldIndex:   -1, // 初始化为 -1，表示未找到
+			disabledFieldIndex: -1,
+			asOfIndex:          -1,
 		}
 
-		// 优先级: translate > db > dictTableTwo > dictTable > enum > dict
+		// 优先级: translate > chain > tree > switch > db > dictTableTwo > dictTable > enum > range > dict
 		if translateTag != "" {
 			// 自定义翻译器
 			parts := strings.Split(translateTag, ",")
 			tagName := parts[0]
 			if translator, ok := dm.loadReg().translators[tagName]; ok {
 				fieldCfg.translator = translator
-				fieldCfg.translatorTag = translateTag
+			}
+			fieldCfg.translateName = tagName
+			fieldCfg.translatorTag = translateTag
+		} else if chainTag != "" {
+			// 链式翻译：上一步的结果作为下一步的 key
+			// 格式: chain:"db:table=dept,key=id,value=parent_id | db:table=dept,key=id,value=name"
+			if c := dm.parseChainTag(chainTag); c != nil {
+				fieldCfg.translator = c
+				fieldCfg.translatorTag = chainTag
+			}
+		} else if treeTag != "" {
+			// 树形字典：完整路径 / 根 / 第 N 层祖先
+			// 格式: tree:"region,sep=/" 或 tree:"region,ancestor=1,source=dictTable"
+			if t := dm.parseTreeTag(treeTag); t != nil {
+				fieldCfg.translator = t
+				fieldCfg.translatorTag = treeTag
+			}
+		} else if switchTag != "" {
+			// 多态字典：按兄弟字段的值选来源
+			// 格式: switch:"TargetType; user => db:table=user,key=id,value=name; device => dictTable:device; default => dict:unknown"
+			if s := dm.parseSwitchTag(rt, switchTag); s != nil {
+				fieldCfg.selector = s
+				fieldCfg.translatorTag = switchTag
 			}
 		} else if dbTag != "" {
 			// 数据库翻译（类似 Easy Trans 的自动查表）
-			// 格式: db:"table=user,key=id,value=name"
-			translator := parseDBTag(dbTag)
-			if translator != nil {
-				fieldCfg.translator = translator
+			// 格式: db:"table=user,key=id,value=name"；复合键: db:"table=t,key=org_id+code,value=name,from=OrgID+Code"
+			// 多列：value=a+b 的列数须与 dictField 的目标数一致，否则标签忽略（不把编码后的整行写进目标）
+			if spec, ok := parseDBSpec(dbTag); ok && spec.targetsMatch(dictFieldTag) && fieldCfg.resolveFrom(rt, spec.from) {
+				fieldCfg.translator = createDBTranslator(spec.table, spec.keyField, spec.valueField)
 				fieldCfg.translatorTag = dbTag
 			}
 		} else if dictTableTwoTag != "" {
-			// 双表字典翻译（字典类型表+字典数据表）
-			translator := createDictTableTwoTranslator(dictTableTwoTag)
-			fieldCfg.translator = translator
+			// 双表字典翻译（字典类型表+字典数据表）：逗号前是字典类型编码，其后是选项
+			// dictTableTwo:"@Field" 时字典类型取兄弟字段的值
+			dictType, _ := splitTag(dictTableTwoTag)
+			if strings.HasPrefix(dictType, "@") {
+				fieldCfg.selector = dm.newAtSelector(rt, "dictTableTwo", dictType)
+			} else {
+				fieldCfg.translator = createDictTableTwoTranslator(dictType)
+			}
 			fieldCfg.translatorTag = dictTableTwoTag
 		} else if dictTableTag != "" {
-			// 字典表翻译（从数据库字典表读取，单表）
-			translator := createDictTableTranslator(dictTableTag)
-			fieldCfg.translator = translator
+			// 字典表翻译（从数据库字典表读取，单表）：逗号前是字典类型，其后是选项
+			// dictTable:"@Field" 时字典类型取兄弟字段的值
+			dictType, _ := splitTag(dictTableTag)
+			if strings.HasPrefix(dictType, "@") {
+				fieldCfg.selector = dm.newAtSelector(rt, "dictTable", dictType)
+			} else {
+				fieldCfg.translator = createDictTableTranslator(dictType)
+			}
 			fieldCfg.translatorTag = dictTableTag
 		} else if enumTag != "" {
-			// 枚举翻译
-			fieldCfg.translator = DefaultEnumTranslator()
+			// 枚举翻译；enum:"@Field" 时枚举名取兄弟字段的值；enum:"perm,flags" 为位掩码枚举
+			if ft, sep := parseFlagTag(enumTag); ft != nil {
+				fieldCfg.translator = ft
+				fieldCfg.flagSep = sep
+			} else if strings.HasPrefix(enumTag, "@") {
+				fieldCfg.selector = dm.newAtSelector(rt, "enum", enumTag)
+			} else {
+				fieldCfg.translator = DefaultEnumTranslator()
+			}
 			fieldCfg.translatorTag = enumTag
+		} else if rangeTag != "" {
+			// 区间字典（RegisterRangeDict）：数值落在哪个左闭右开区间就取哪个标签
+			fieldCfg.translator = rangeTranslator{}
+			fieldCfg.translatorTag = rangeTag
 		} else if dictTag != "" {
 			// 字典翻译（兼容旧版本，内存字典）：逗号前是字典名，这里拆好，热路径不再 Split
 			fieldCfg.translatorTag = dictTag
@@ -499,19 +786,34 @@ func (dm *DictManager) getOrCreateConfig(rt reflect.Type) *structConfig {
 			if j := strings.IndexByte(dictTag, ','); j >= 0 {
 				fieldCfg.dictName = dictTag[:j]
 			}
+			// dict:"@Field" 时字典名取兄弟字段的值
+			if strings.HasPrefix(fieldCfg.dictName, "@") {
+				fieldCfg.selector = dm.newAtSelector(rt, "dict", fieldCfg.dictName)
+				fieldCfg.dictName = ""
+			}
+		} else {
+			// 没有翻译标签：类型实现 DictLabeler 或由 RegisterEnumOf 注册时自动翻译
+			fieldCfg.implicitEnum(rt, fieldType)
 		}
 
 		if fieldCfg.translator != nil || fieldCfg.translatorTag != "" {
 			// 查找并缓存目标字段索引，避免运行时查找
-			if fieldCfg.targetField != "" {
-				for j := 0; j < rt.NumField(); j++ {
-					structField := rt.Field(j)
-					if structField.Name == fieldCfg.targetField || strings.EqualFold(structField.Name, fieldCfg.targetField) {
-						fieldCfg.targetFieldIndex = j
-						break
-					}
+			if strings.Contains(fieldCfg.targetField, ",") {
+				for _, name := range strings.Split(fieldCfg.targetField, ",") {
+					fieldCfg.targetIndexes = append(fieldCfg.targetIndexes, fieldIndexByName(rt, strings.TrimSpace(name)))
 				}
+			} else if fieldCfg.targetField != "" {
+				fieldCfg.targetFieldIndex = fieldIndexByName(rt, fieldCfg.targetField)
+			}
+			_, opts := splitTag(fieldCfg.translatorTag)
+			if opts["disabledField"] != "" {
+				fieldCfg.disabledFieldIndex = fieldIndexByName(rt, opts["disabledField"])
 			}
+			if opts["asOf"] != "" {
+				fieldCfg.asOfIndex = fieldIndexByName(rt, opts["asOf"])
+			}
+			fieldCfg.clearOnMiss = opts["miss"] == "nil"
+			fieldCfg.normalize = !fieldCfg.rawSource && needsNormalize(fieldType.Type)
 			config.fields = append(config.fields, fieldCfg)
 		}
 	}
@@ -522,25 +824,13 @@ func (dm *DictManager) getOrCreateConfig(rt reflect.Type) *structConfig {
 		config.byIndex[config.fields[i].fieldIndex] = &config.fields[i]
 	}
 
-	// 预算遍历步骤：只有"嵌套结构（struct / *struct / []struct / []*struct）"或"带翻译标签"的导出字段才进热路径
+	// 预算遍历步骤：只有"嵌套结构（struct / *struct / []struct / []*struct，及可能装着结构体的 map / 数组 / any）"或"带翻译标签"的导出字段才进热路径
 	for i := 0; i < rt.NumField(); i++ {
 		sf := rt.Field(i)
 		if !sf.IsExported() {
 			continue
 		}
-		st := fieldStep{index: i, cfg: config.byIndex[i]}
-		switch ft := sf.Type; ft.Kind() {
-		case reflect.Struct:
-			st.nested = nestedStruct
-		case reflect.Ptr:
-			if ft.Elem().Kind() == reflect.Struct {
-				st.nested = nestedPtr
-			}
-		case reflect.Slice:
-			if et := ft.Elem(); et.Kind() == reflect.Struct || (et.Kind() == reflect.Ptr && et.Elem().Kind() == reflect.Struct) {
-				st.nested = nestedSlice
-			}
-		}
+		st := fieldStep{index: i, cfg: config.byIndex[i], nested: nestedKindOf(sf.Type)}
 		if st.nested != nestedNone || st.cfg != nil {
 			config.steps = append(config.steps, st)
 		}
@@ -557,15 +847,21 @@ func (dm *DictManager) getOrCreateConfig(rt reflect.Type) *structConfig {
 
 	// 可能需要 DB 预取：自身字段挂了 lookupTranslator，或有嵌套 struct / ptr / slice（里面可能有）
 	for i := range config.fields {
-		if _, ok := config.fields[i].translator.(*lookupTranslator); ok {
+		switch config.fields[i].translator.(type) {
+		case *lookupTranslator, prefetcher:
 			config.mayLookup = true
-			break
+		}
+		if config.fields[i].selector != nil {
+			config.mayLookup = true // 选中哪个来源要看数据，按可能查库处理
+		}
+		if config.fields[i].translateName != "" {
+			config.translate = true
 		}
 	}
 	if !config.mayLookup {
 		for i := 0; i < rt.NumField(); i++ {
 			switch rt.Field(i).Type.Kind() {
-			case reflect.Struct, reflect.Ptr, reflect.Slice:
+			case reflect.Struct, reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map, reflect.Interface:
 				if rt.Field(i).IsExported() {
 					config.mayLookup = true
 				}
@@ -580,6 +876,64 @@ func (dm *DictManager) getOrCreateConfig(rt reflect.Type) *structConfig {
 	return config
 }
 
+// resolveFrom 解析复合键的源字段下标；任一字段不存在或未导出返回 false（该 db 标签忽略）
+func (fc *fieldConfig) resolveFrom(rt reflect.Type, from []string) bool {
+	if len(from) == 0 {
+		return true
+	}
+	fc.fromIndexes = make([]int, len(from))
+	for i, name := range from {
+		if fc.fromIndexes[i] = fieldIndexByName(rt, name); fc.fromIndexes[i] < 0 || !rt.Field(fc.fromIndexes[i]).IsExported() {
+			fc.fromIndexes = nil
+			return false
+		}
+	}
+	return true
+}
+
+// compositeSource 把复合键各源字段的值（归一化后）拼成 CompositeKey；任一字段为 nil / 无效时返回 false
+func compositeSource(structValue reflect.Value, indexes []int) (string, bool) {
+	values := make([]string, len(indexes))
+	for i, idx := range indexes {
+		v, ok := sourceString(structValue.Field(idx))
+		if !ok {
+			return "", false
+		}
+		values[i] = v
+	}
+	return CompositeKey(values...), true
+}
+
+// fieldIndexByName 按名字找字段下标（先精确、再大小写不敏感），找不到返回 -1
+func fieldIndexByName(rt reflect.Type, name string) int {
+	for j := 0; j < rt.NumField(); j++ {
+		if fn := rt.Field(j).Name; fn == name || strings.EqualFold(fn, name) {
+			return j
+		}
+	}
+	return -1
+}
+
+// splitTag 拆出标签逗号前的名字与其后的 key=value 选项（如 dictTable:"sex,disabledField=SexDisabled"）；
+// 没有选项时 opts 为 nil，不带 = 的开关选项值为空串
+func splitTag(tag string) (name string, opts map[string]string) {
+	name, rest, found := strings.Cut(tag, ",")
+	if !found {
+		return name, nil
+	}
+	for _, part := range strings.Split(rest, ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(part), "=")
+		if k == "" {
+			continue
+		}
+		if opts == nil {
+			opts = make(map[string]string)
+		}
+		opts[k] = v // 不带 = 的开关选项（如 flags）值为空串
+	}
+	return name, opts
+}
+
 // translateSliceV 翻译嵌套切片（共享父结构体的 visited，因为元素可能指回父节点）
 func (dm *DictManager) translateSliceV(sliceValue reflect.Value, seen *walk) error {
 	for i := 0; i < sliceValue.Len(); i++ {
@@ -589,134 +943,217 @@ func (dm *DictManager) translateSliceV(sliceValue reflect.Value, seen *walk) err
 			continue
 		}
 		// 指针元素才可能成环，viaPtr 交给 translateStructV 记 visited
+		if seen.labels != nil {
+			seen.enter("[" + strconv.Itoa(i) + "]")
+		}
 		if err := dm.translateStructV(elem, seen, raw.Kind() == reflect.Ptr); err != nil {
 			return err
 		}
+		if seen.labels != nil {
+			seen.leave()
+		}
 	}
 	return nil
 }
 
 // translateField 翻译字段
-func (dm *DictManager) translateField(field reflect.Value, fieldCfg *fieldConfig, structValue reflect.Value) error {
+func (dm *DictManager) translateField(field reflect.Value, fieldCfg *fieldConfig, structValue reflect.Value, w *walk) error {
 	// 获取字典（原子读注册表快照，无锁；字典名已在配置缓存里拆好）
-	dict := dm.loadReg().dicts[fieldCfg.dictName]
+	dict := dm.dictFor(w.overlay, fieldCfg.dictName)
 	if dict == nil {
-		return nil // 字典不存在，跳过
+		return fieldCfg.miss(structValue) // 字典不存在，跳过
 	}
 
-	// 获取源字段值
-	if field.Kind() != reflect.String {
-		return nil // 只支持字符串类型
+	// 获取源字段值：string 走快路径，其余（整数、指针、sql.Null* 等）归一化成字符串
+	var sourceValue string
+	if field.Kind() == reflect.String && !fieldCfg.normalize {
+		sourceValue = field.String()
+	} else if v, ok := sourceString(field); ok {
+		sourceValue = v
 	}
-
-	sourceValue := field.String()
 	if sourceValue == "" {
-		return nil
+		return fieldCfg.miss(structValue)
 	}
 
 	// 获取翻译后的值
 	translatedValue := dict[sourceValue]
 	if translatedValue == "" {
-		return nil
+		return fieldCfg.miss(structValue)
 	}
 
-	// 获取目标字段名
-	dictFieldTag := fieldCfg.targetField
-	if dictFieldTag == "" {
-		return nil
+	// 设置目标字段（下标在配置缓存里已按名字解析，大小写不敏感）
+	if w.labels != nil {
+		w.record(fieldCfg.fieldName, translatedValue)
 	}
+	fieldCfg.write(structValue, translatedValue, sourceValue)
+	return nil
+}
 
-	// 优先使用缓存的字段索引（O(1)）
-	if fieldCfg.targetFieldIndex >= 0 {
-		targetField := structValue.Field(fieldCfg.targetFieldIndex)
-		if targetField.CanSet() && targetField.Kind() == reflect.String {
-			targetField.SetString(translatedValue)
-			return nil
+// translateFieldWithTranslator 使用翻译器翻译字段
+func (dm *DictManager) translateFieldWithTranslator(field reflect.Value, fieldCfg *fieldConfig, structValue reflect.Value, w *walk) error {
+	// 获取源字段值：指针 / sql.Null* / Valuer / TextMarshaler 先归一化，nil 与无效值跳过
+	var sourceValue any
+	switch {
+	case fieldCfg.rawSource:
+		if (field.Kind() == reflect.Ptr || field.Kind() == reflect.Interface) && field.IsNil() {
+			return dm.miss(fieldCfg, structValue, w)
+		}
+		sourceValue = field.Interface()
+	case fieldCfg.normalize:
+		v, ok := sourceKey(field)
+		if !ok {
+			return dm.miss(fieldCfg, structValue, w)
+		}
+		sourceValue = v
+	default:
+		switch field.Kind() {
+		case reflect.String:
+			sourceValue = field.String()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			sourceValue = field.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			sourceValue = field.Uint()
+		default:
+			sourceValue = field.Interface()
 		}
 	}
 
-	// 回退到遍历查找
-	structType := structValue.Type()
-	for i := 0; i < structType.NumField(); i++ {
-		structField := structType.Field(i)
-		// 支持字段名匹配（大小写不敏感）
-		if structField.Name == dictFieldTag || strings.EqualFold(structField.Name, dictFieldTag) {
-			targetField := structValue.Field(i)
-			if targetField.CanSet() && targetField.Kind() == reflect.String {
-				targetField.SetString(translatedValue)
-				break
-			}
+	// 复合键：源值改为 from 各字段拼成的 CompositeKey；任一部分为 nil 时跳过
+	if fieldCfg.fromIndexes != nil {
+		key, ok := compositeSource(structValue, fieldCfg.fromIndexes)
+		if !ok {
+			return dm.miss(fieldCfg, structValue, w)
 		}
+		sourceValue = key
 	}
 
-	return nil
-}
+	// asOf 选项：把字段里的日期挂到 ctx 上，带生效区间的字典按它取标签
+	ctx := w.ctx
+	if fieldCfg.asOfIndex >= 0 {
+		if at, ok := asOfValue(structValue.Field(fieldCfg.asOfIndex)); ok {
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			ctx = ContextWithAsOf(ctx, at)
+		}
+	}
 
-// translateFieldWithTranslator 使用翻译器翻译字段
-func (dm *DictManager) translateFieldWithTranslator(field reflect.Value, fieldCfg *fieldConfig, structValue reflect.Value, w *walk) error {
-	// 获取源字段值
-	var sourceValue any
-	switch field.Kind() {
-	case reflect.String:
-		sourceValue = field.String()
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		sourceValue = field.Int()
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		sourceValue = field.Uint()
-	default:
-		sourceValue = field.Interface()
+	// 多态字典：按兄弟字段的值选出本元素的来源；没有匹配的来源时跳过
+	translator, tag := fieldCfg.translator, fieldCfg.translatorTag
+	if w.overlay != nil && fieldCfg.translateName != "" {
+		if t, ok := w.overlay.translators[fieldCfg.translateName]; ok {
+			translator = t
+		}
+	}
+	if translator == nil && fieldCfg.selector == nil {
+		return nil // translate 标签的翻译器未注册
+	}
+	if fieldCfg.selector != nil {
+		st, ok := fieldCfg.selector.pick(structValue)
+		if !ok {
+			return dm.miss(fieldCfg, structValue, w)
+		}
+		translator, tag = st.tr, st.tag
 	}
 
-	// 收集模式：只记下 DB 类翻译器要查的 key，不翻译
+	// 收集模式：只记下 DB 类翻译器要查的 key，不翻译；按翻译器（多态时即选中的字典）和缓存作用域分组
 	if w.collect != nil {
-		if lt, ok := fieldCfg.translator.(*lookupTranslator); ok {
-			w.collect[lt] = append(w.collect[lt], fmt.Sprintf("%v", sourceValue))
-		}
+		w.add(ctx, translator, sourceValue)
 		return nil
 	}
 
 	// 调用翻译器：带 ctx 且翻译器支持时走 ContextTranslator
 	var translatedValue string
 	var err error
-	if ct, ok := fieldCfg.translator.(ContextTranslator); ok && w.ctx != nil {
-		translatedValue, err = ct.TranslateContext(w.ctx, sourceValue, fieldCfg.fieldName, fieldCfg.translatorTag)
+	if ct, ok := translator.(ContextTranslator); ok && ctx != nil {
+		translatedValue, err = ct.TranslateContext(ctx, sourceValue, fieldCfg.fieldName, tag)
 	} else {
-		translatedValue, err = fieldCfg.translator.Translate(sourceValue, fieldCfg.fieldName, fieldCfg.translatorTag)
+		translatedValue, err = translator.Translate(sourceValue, fieldCfg.fieldName, tag)
 	}
 	if err != nil {
 		return err
 	}
 
 	if translatedValue == "" {
-		return nil
+		return fieldCfg.miss(structValue)
 	}
 
-	// 设置目标字段
-	if fieldCfg.targetField == "" {
+	// 多目标（db 多列取值）：按位置拆开依次写入
+	if fieldCfg.targetIndexes != nil {
+		for i, v := range SplitCompositeKey(translatedValue) {
+			if i >= len(fieldCfg.targetIndexes) || fieldCfg.targetIndexes[i] < 0 {
+				continue
+			}
+			if target := structValue.Field(fieldCfg.targetIndexes[i]); v != "" {
+				v = fieldCfg.resolveDisabled(v, structValue)
+				if w.labels != nil {
+					w.record(structValue.Type().Field(fieldCfg.targetIndexes[i]).Name, v)
+				}
+				setTarget(target, v, sourceValue)
+			} else if fieldCfg.clearOnMiss {
+				clearTarget(target)
+			}
+		}
 		return nil
 	}
+	translatedValue = fieldCfg.resolveDisabled(translatedValue, structValue)
 
-	// 优先使用缓存的字段索引（O(1)），否则回退到遍历查找（O(n)）
-	if fieldCfg.targetFieldIndex >= 0 {
-		targetField := structValue.Field(fieldCfg.targetFieldIndex)
-		if targetField.CanSet() && targetField.Kind() == reflect.String {
-			targetField.SetString(translatedValue)
-			return nil
+	// 位掩码枚举：按目标类型展开（[]string 逐个填入，string 用 sep 连接）
+	if fieldCfg.flagSep != "" {
+		if w.labels != nil {
+			w.record(fieldCfg.fieldName, strings.Join(SplitCompositeKey(translatedValue), fieldCfg.flagSep))
+		}
+		if fieldCfg.targetFieldIndex >= 0 {
+			setFlagTarget(structValue.Field(fieldCfg.targetFieldIndex), translatedValue, fieldCfg.flagSep, sourceValue)
 		}
+		return nil
 	}
 
-	// 回退到遍历查找（兼容旧代码或字段索引未找到的情况）
-	structType := structValue.Type()
-	for i := 0; i < structType.NumField(); i++ {
-		structField := structType.Field(i)
-		if structField.Name == fieldCfg.targetField || strings.EqualFold(structField.Name, fieldCfg.targetField) {
-			targetField := structValue.Field(i)
-			if targetField.CanSet() && targetField.Kind() == reflect.String {
-				targetField.SetString(translatedValue)
-				break
-			}
+	// 设置目标字段（下标在配置缓存里已按名字解析，大小写不敏感）
+	if w.labels != nil {
+		w.record(fieldCfg.fieldName, translatedValue)
+	}
+	fieldCfg.write(structValue, translatedValue, sourceValue)
+	return nil
+}
+
+// miss 翻译器路径的缺失处理：收集模式只收集 key，不动目标字段
+func (dm *DictManager) miss(fieldCfg *fieldConfig, structValue reflect.Value, w *walk) error {
+	if w.collect != nil {
+		return nil
+	}
+	return fieldCfg.miss(structValue)
+}
+
+// resolveDisabled 展开后端的停用标记（MarkDisabled）：配置了 disabledField 时把它置 true、返回原标签，
+// 否则返回标签 + 后缀；未标记的值原样返回
+func (fc *fieldConfig) resolveDisabled(v string, structValue reflect.Value) string {
+	label, suffix, disabled := splitDisabled(v)
+	if !disabled {
+		return v
+	}
+	if fc.disabledFieldIndex >= 0 {
+		if f := structValue.Field(fc.disabledFieldIndex); f.CanSet() && f.Kind() == reflect.Bool {
+			f.SetBool(true)
 		}
+		return label
 	}
+	return label + suffix
+}
 
-	return nil
+var timeType = reflect.TypeOf(time.Time{})
+
+// asOfValue 取 asOf 字段的日期：time.Time（零值跳过）或非 nil 的 *time.Time
+func asOfValue(f reflect.Value) (time.Time, bool) {
+	if f.Kind() == reflect.Ptr {
+		if f.IsNil() {
+			return time.Time{}, false
+		}
+		f = f.Elem()
+	}
+	if f.Type() != timeType {
+		return time.Time{}, false
+	}
+	t := f.Interface().(time.Time)
+	return t, !t.IsZero()
 }
@@ -28,7 +28,7 @@ func CreateDictTableTranslatorFromDB(db *sql.DB, tableName string) DictTableTran
 }
 
 // CreateDictTableTranslatorFromDBWithConfig 从数据库创建字典表翻译器（自定义表结构）。
-// 返回值同时实现 DictTableContextTranslator / DictTableBatchTranslator / DictTableLoader。
+// 返回值同时实现 DictTableContextTranslator / DictTableBatchTranslator / DictTableLoader / DictTableBulkLoader / DictTableTreeTranslator（需配置 ParentField）/ CacheScoper。
 func CreateDictTableTranslatorFromDBWithConfig(db *sql.DB, config *TableConfig) DictTableTranslator {
 	if config == nil {
 		config = DefaultTableConfig("sys_dict")
@@ -47,43 +47,102 @@ func (t *sqlDictTable) QueryDict(dictType, dictKey string) (string, error) {
 }
 
 func (t *sqlDictTable) QueryDictContext(ctx context.Context, dictType, dictKey string) (string, error) {
-	query, args := t.cfg.BuildQueryWithKey(dictType, dictKey)
-	var result string
-	if err := t.db.QueryRowContext(ctx, query, args...).Scan(&result); err != nil {
-		if err == sql.ErrNoRows {
-			return "", nil // 未找到记录
-		}
-		return "", fmt.Errorf("查询字典表失败: %w", err)
-	}
-	return result, nil
+	query, args := t.cfg.Resolve(ctx).BuildQueryWithKey(dictType, dictKey)
+	return queryLabel(ctx, t.db, t.cfg, query, args, "查询字典表失败")
 }
 
 func (t *sqlDictTable) QueryDictBatch(ctx context.Context, dictType string, dictKeys []string) (map[string]string, error) {
 	if len(dictKeys) == 0 {
 		return map[string]string{}, nil
 	}
-	query, args := t.cfg.BuildQueryIn(dictType, dictKeys)
-	return scanKeyValues(ctx, t.db, query, args, "查询字典表失败")
+	query, args := t.cfg.Resolve(ctx).BuildQueryIn(dictType, dictKeys)
+	return scanKeyValues(ctx, t.db, query, args, t.cfg, "查询字典表失败")
 }
 
 func (t *sqlDictTable) LoadDict(ctx context.Context, dictType string) (map[string]string, error) {
-	query, args := t.cfg.BuildQueryAll(dictType)
-	return scanKeyValues(ctx, t.db, query, args, "预加载字典表失败")
+	query, args := t.cfg.Resolve(ctx).BuildQueryAll(dictType)
+	return scanKeyValues(ctx, t.db, query, args, nil, "预加载字典表失败")
+}
+
+func (t *sqlDictTable) LoadAll(ctx context.Context) (map[string]map[string]string, error) {
+	query, args := t.cfg.Resolve(ctx).BuildQueryAllTypes()
+	return scanTypeKeyValues(ctx, t.db, query, args, "预加载全部字典失败")
+}
+
+func (t *sqlDictTable) QueryNodes(ctx context.Context, dictType string, dictKeys []string) (map[string]TreeNode, error) {
+	if t.cfg.ParentField == "" {
+		return nil, fmt.Errorf("查询树形字典失败: TableConfig.ParentField 未配置")
+	}
+	if len(dictKeys) == 0 {
+		return map[string]TreeNode{}, nil
+	}
+	query, args := t.cfg.Resolve(ctx).BuildQueryNodes(dictType, dictKeys)
+	rows, err := t.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("查询树形字典失败: %w", err)
+	}
+	defer rows.Close()
+	out := make(map[string]TreeNode)
+	for rows.Next() {
+		var k, v string
+		var parent sql.NullString
+		if err := rows.Scan(&k, &v, &parent); err != nil {
+			return nil, fmt.Errorf("查询树形字典失败: %w", err)
+		}
+		out[k] = TreeNode{Label: v, Parent: parent.String}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("查询树形字典失败: %w", err)
+	}
+	return out, nil
+}
+
+func (t *sqlDictTable) CacheScope(ctx context.Context) string { return t.cfg.cacheScope(ctx) }
+
+// queryLabel 执行 SELECT value [, status] ... 查单个标签；没有记录返回空串。
+// cfg 标记停用项时（StatusMarkDisabled）第二列是状态值，停用项用 MarkDisabled 标记
+func queryLabel(ctx context.Context, db *sql.DB, cfg *TableConfig, query string, args []any, errPrefix string) (string, error) {
+	var result string
+	var status sql.NullString // 状态列可能为 NULL
+	dest := []any{&result}
+	if cfg.marksDisabled() {
+		dest = append(dest, &status)
+	}
+	if err := db.QueryRowContext(ctx, query, args...).Scan(dest...); err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil // 未找到记录
+		}
+		return "", fmt.Errorf("%s: %w", errPrefix, err)
+	}
+	if cfg.marksDisabled() {
+		result = cfg.markIfDisabled(result, status)
+	}
+	return result, nil
 }
 
-// scanKeyValues 执行 SELECT key, value ... 并装成 map
-func scanKeyValues(ctx context.Context, db *sql.DB, query string, args []any, errPrefix string) (map[string]string, error) {
+// scanKeyValues 执行 SELECT key, value [, status] ... 并装成 map；
+// mark 非 nil 且标记停用项时第三列是状态值（同 queryLabel）
+func scanKeyValues(ctx context.Context, db *sql.DB, query string, args []any, mark *TableConfig, errPrefix string) (map[string]string, error) {
 	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", errPrefix, err)
 	}
 	defer rows.Close()
+	withStatus := mark != nil && mark.marksDisabled()
 	out := make(map[string]string)
 	for rows.Next() {
 		var k, v string
-		if err := rows.Scan(&k, &v); err != nil {
+		var status sql.NullString
+		dest := []any{&k, &v}
+		if withStatus {
+			dest = append(dest, &status)
+		}
+		if err := rows.Scan(dest...); err != nil {
 			return nil, fmt.Errorf("%s: %w", errPrefix, err)
 		}
+		if withStatus {
+			v = mark.markIfDisabled(v, status)
+		}
 		out[k] = v
 	}
 	if err := rows.Err(); err != nil {
@@ -91,3 +150,27 @@ func scanKeyValues(ctx context.Context, db *sql.DB, query string, args []any, er
 	}
 	return out, nil
 }
+
+// scanTypeKeyValues 执行 SELECT type, key, value ... 并按类型分组装成 map
+func scanTypeKeyValues(ctx context.Context, db *sql.DB, query string, args []any, errPrefix string) (map[string]map[string]string, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errPrefix, err)
+	}
+	defer rows.Close()
+	out := make(map[string]map[string]string)
+	for rows.Next() {
+		var typ, k, v string
+		if err := rows.Scan(&typ, &k, &v); err != nil {
+			return nil, fmt.Errorf("%s: %w", errPrefix, err)
+		}
+		if out[typ] == nil {
+			out[typ] = make(map[string]string)
+		}
+		out[typ][k] = v
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", errPrefix, err)
+	}
+	return out, nil
+}
@@ -32,7 +32,7 @@ func CreateDictTableTwoTranslatorFromDB(db *sql.DB, dictTypeTable, dictDataTable
 }
 
 // CreateDictTableTwoTranslatorFromDBWithConfig 从数据库创建双表字典翻译器（自定义表结构）。
-// 返回值同时实现 DictTableContextTranslator / DictTableBatchTranslator / DictTableLoader。
+// 返回值同时实现 DictTableContextTranslator / DictTableBatchTranslator / DictTableLoader / DictTableBulkLoader / CacheScoper。
 func CreateDictTableTwoTranslatorFromDBWithConfig(db *sql.DB, typeConfig, dataConfig *TableConfig) DictTableTwoTranslator {
 	if typeConfig == nil {
 		typeConfig = DefaultDictTypeTableConfig("sys_dict_type")
@@ -51,7 +51,7 @@ type sqlDictTableTwo struct {
 }
 
 func (t *sqlDictTableTwo) typeEnabled(ctx context.Context, dictTypeCode string) (bool, error) {
-	query, args := t.typeCfg.BuildTypeCheckQuery(dictTypeCode)
+	query, args := t.typeCfg.Resolve(ctx).BuildTypeCheckQuery(dictTypeCode)
 	var n int
 	if err := t.db.QueryRowContext(ctx, query, args...).Scan(&n); err != nil {
 		return false, fmt.Errorf("查询字典类型失败: %w", err)
@@ -59,6 +59,15 @@ func (t *sqlDictTableTwo) typeEnabled(ctx context.Context, dictTypeCode string)
 	return n > 0, nil
 }
 
+// CacheScope 类型表与数据表的 ctx 条件都影响结果，两者一起并入缓存键
+func (t *sqlDictTableTwo) CacheScope(ctx context.Context) string {
+	ts, ds := t.typeCfg.cacheScope(ctx), t.dataCfg.cacheScope(ctx)
+	if ts == "" || ds == "" {
+		return ts + ds
+	}
+	return ts + "\x00" + ds
+}
+
 func (t *sqlDictTableTwo) QueryDict(dictTypeCode, dictKey string) (string, error) {
 	return t.QueryDictContext(context.Background(), dictTypeCode, dictKey)
 }
@@ -68,15 +77,8 @@ func (t *sqlDictTableTwo) QueryDictContext(ctx context.Context, dictTypeCode, di
 	if err != nil || !ok {
 		return "", err
 	}
-	query, args := t.dataCfg.BuildQueryWithKey(dictTypeCode, dictKey)
-	var result string
-	if err := t.db.QueryRowContext(ctx, query, args...).Scan(&result); err != nil {
-		if err == sql.ErrNoRows {
-			return "", nil
-		}
-		return "", fmt.Errorf("查询字典数据失败: %w", err)
-	}
-	return result, nil
+	query, args := t.dataCfg.Resolve(ctx).BuildQueryWithKey(dictTypeCode, dictKey)
+	return queryLabel(ctx, t.db, t.dataCfg, query, args, "查询字典数据失败")
 }
 
 func (t *sqlDictTableTwo) QueryDictBatch(ctx context.Context, dictTypeCode string, dictKeys []string) (map[string]string, error) {
@@ -87,8 +89,8 @@ func (t *sqlDictTableTwo) QueryDictBatch(ctx context.Context, dictTypeCode strin
 	if err != nil || !ok {
 		return nil, err
 	}
-	query, args := t.dataCfg.BuildQueryIn(dictTypeCode, dictKeys)
-	return scanKeyValues(ctx, t.db, query, args, "查询字典数据失败")
+	query, args := t.dataCfg.Resolve(ctx).BuildQueryIn(dictTypeCode, dictKeys)
+	return scanKeyValues(ctx, t.db, query, args, t.dataCfg, "查询字典数据失败")
 }
 
 func (t *sqlDictTableTwo) LoadDict(ctx context.Context, dictTypeCode string) (map[string]string, error) {
@@ -96,6 +98,20 @@ func (t *sqlDictTableTwo) LoadDict(ctx context.Context, dictTypeCode string) (ma
 	if err != nil || !ok {
 		return nil, err
 	}
-	query, args := t.dataCfg.BuildQueryAll(dictTypeCode)
-	return scanKeyValues(ctx, t.db, query, args, "预加载字典数据失败")
+	query, args := t.dataCfg.Resolve(ctx).BuildQueryAll(dictTypeCode)
+	return scanKeyValues(ctx, t.db, query, args, nil, "预加载字典数据失败")
+}
+
+// LoadAll 一次查询取出所有启用类型的数据：数据表按类型字段 IN (启用类型子查询) 过滤
+func (t *sqlDictTableTwo) LoadAll(ctx context.Context) (map[string]map[string]string, error) {
+	query, args := t.dataCfg.Resolve(ctx).BuildQueryAllTypes()
+	sub, subArgs := t.typeCfg.Resolve(ctx).BuildTypeListQuery()
+	if len(args) > 0 {
+		query += " AND "
+	} else {
+		query += " WHERE "
+	}
+	query += t.dataCfg.Fields.TypeField + " IN (" + sub + ")"
+	args = append(args, subArgs...)
+	return scanTypeKeyValues(ctx, t.db, query, args, "预加载全部字典数据失败")
 }
@@ -3,6 +3,7 @@ package dict
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
 	"sync/atomic"
 )
@@ -53,10 +54,11 @@ func GetEnum(name string) map[string]string {
 
 // Translate 实现 Translator 接口
 func (e *EnumTranslator) Translate(value any, fieldName string, tagValue string) (string, error) {
-	// tagValue 是枚举名称
-	enum := e.load()[tagValue]
+	// tagValue 是枚举名称（逗号后是 miss=nil 等字段选项）
+	name, _, _ := strings.Cut(tagValue, ",")
+	enum := e.load()[name]
 	if enum == nil {
-		return "", fmt.Errorf("enum '%s' not found", tagValue)
+		return "", fmt.Errorf("enum '%s' not found", name)
 	}
 
 	// 将 value 转换为字符串
@@ -0,0 +1,117 @@
+package dict
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// DictLabeler 自带标签的类型：字段类型实现它时不用写标签，结果写入 dictField 指定的字段，
+// 没写 dictField 时写入同名加 Name 后缀的字段（Status -> StatusName）
+type DictLabeler interface {
+	DictLabel() string
+}
+
+// EnumItem 类型化枚举的一项
+type EnumItem[T ~int | ~string] struct {
+	Value T
+	Label string
+}
+
+// typedEnum RegisterEnumOf 登记的类型化枚举：name 是对应的 RegisterEnum 名，items 是按值排好序的 []EnumItem[T]
+type typedEnum struct {
+	name  string
+	items any
+}
+
+// typedEnums 类型 -> 类型化枚举，写时复制；gen 每次注册加一，各 DictManager 据此清掉过期的结构体配置
+var typedEnums struct {
+	m   atomic.Pointer[map[reflect.Type]typedEnum]
+	mu  sync.Mutex
+	gen atomic.Uint64
+}
+
+func typedEnumOf(rt reflect.Type) (typedEnum, bool) {
+	if m := typedEnums.m.Load(); m != nil {
+		te, ok := (*m)[rt]
+		return te, ok
+	}
+	return typedEnum{}, false
+}
+
+// RegisterEnumOf 用 Go 类型注册枚举（type OrderStatus int 这类常量），同时按 name 注册普通枚举，
+// enum:"name" 标签照常可用。该类型的字段不写标签也会翻译（目标字段规则同 DictLabeler）；
+// 注册后清空配置缓存，之前翻译过的结构体也按新枚举
+func RegisterEnumOf[T ~int | ~string](name string, enum map[T]string) {
+	m := make(map[string]string, len(enum))
+	items := make([]EnumItem[T], 0, len(enum))
+	for k, v := range enum {
+		m[fmt.Sprintf("%v", k)] = v
+		items = append(items, EnumItem[T]{Value: k, Label: v})
+	}
+	slices.SortFunc(items, func(a, b EnumItem[T]) int { return cmp.Compare(a.Value, b.Value) })
+	RegisterEnum(name, m)
+
+	typedEnums.mu.Lock()
+	defer typedEnums.mu.Unlock()
+	var old map[reflect.Type]typedEnum
+	if p := typedEnums.m.Load(); p != nil {
+		old = *p
+	}
+	next := make(map[reflect.Type]typedEnum, len(old)+1)
+	for k, v := range old {
+		next[k] = v
+	}
+	next[reflect.TypeOf((*T)(nil)).Elem()] = typedEnum{name: name, items: items}
+	typedEnums.m.Store(&next)
+	typedEnums.gen.Add(1)
+}
+
+// EnumValues 列出 RegisterEnumOf 注册的某个类型的全部取值（按值升序），用于下拉框等；未注册返回 nil
+func EnumValues[T ~int | ~string]() []EnumItem[T] {
+	te, ok := typedEnumOf(reflect.TypeOf((*T)(nil)).Elem())
+	if !ok {
+		return nil
+	}
+	return slices.Clone(te.items.([]EnumItem[T]))
+}
+
+// labelerTranslator 字段类型实现 DictLabeler 时使用；源值是字段的原始值（fieldConfig.rawSource）
+type labelerTranslator struct{}
+
+func (labelerTranslator) Translate(value any, _ string, _ string) (string, error) {
+	if l, ok := value.(DictLabeler); ok {
+		return l.DictLabel(), nil
+	}
+	return "", nil
+}
+
+var dictLabelerType = reflect.TypeOf((*DictLabeler)(nil)).Elem()
+
+// implicitEnum 没写翻译标签的字段：类型实现 DictLabeler 或由 RegisterEnumOf 注册时自动翻译，
+// 找不到目标字段时返回 false
+func (fc *fieldConfig) implicitEnum(rt reflect.Type, sf reflect.StructField) bool {
+	if !sf.IsExported() {
+		return false
+	}
+	if sf.Type.Implements(dictLabelerType) {
+		fc.translator = labelerTranslator{}
+		fc.rawSource = true
+	} else if te, ok := typedEnumOf(sf.Type); ok {
+		fc.translator = DefaultEnumTranslator()
+		fc.translatorTag = te.name
+	} else {
+		return false
+	}
+	if fc.targetField == "" {
+		fc.targetField = sf.Name + "Name"
+	}
+	if i := fieldIndexByName(rt, fc.targetField); i < 0 || i == fc.fieldIndex {
+		fc.translator, fc.translatorTag, fc.targetField = nil, "", ""
+		return false
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+package dict
+
+import (
+	"reflect"
+	"testing"
+)
+
+type orderStatus int
+
+const (
+	orderPending orderStatus = iota + 1
+	orderPaid
+	orderShipped
+)
+
+type payChannel string
+
+func (c payChannel) DictLabel() string {
+	switch c {
+	case "wx":
+		return "微信"
+	case "ali":
+		return "支付宝"
+	}
+	return ""
+}
+
+func TestRegisterEnumOf(t *testing.T) {
+	RegisterEnumOf("orderStatus", map[orderStatus]string{
+		orderShipped: "已发货",
+		orderPending: "待支付",
+		orderPaid:    "已支付",
+	})
+
+	type Order struct {
+		Status      orderStatus
+		StatusName  string
+		Channel     payChannel
+		ChannelName string
+		Legacy      string `enum:"orderStatus" dictField:"LegacyName"`
+		LegacyName  string
+		Other       orderStatus `dictField:"OtherLabel"`
+		OtherLabel  string
+		NoTarget    orderStatus
+	}
+	o := &Order{Status: orderPaid, Channel: "wx", Legacy: "3", Other: orderPending, NoTarget: orderPaid}
+	if err := Translate(o); err != nil {
+		t.Fatal(err)
+	}
+	if o.StatusName != "已支付" || o.ChannelName != "微信" || o.LegacyName != "已发货" || o.OtherLabel != "待支付" {
+		t.Fatalf("类型化枚举自动翻译失败: %+v", o)
+	}
+
+	items := EnumValues[orderStatus]()
+	want := []EnumItem[orderStatus]{{orderPending, "待支付"}, {orderPaid, "已支付"}, {orderShipped, "已发货"}}
+	if !reflect.DeepEqual(items, want) {
+		t.Fatalf("列出枚举值不对: %+v", items)
+	}
+	if EnumValues[payChannel]() != nil {
+		t.Fatalf("未注册的类型应返回 nil")
+	}
+}
+
+type lateStatus int
+
+func TestRegisterEnumOfAfterTranslate(t *testing.T) {
+	type Row struct {
+		Status     lateStatus
+		StatusName string
+	}
+	dm := NewDictManager()
+	r := &Row{Status: 1}
+	if err := Translate(r); err != nil || r.StatusName != "" {
+		t.Fatalf("注册前不应翻译: %v %+v", err, r)
+	}
+	if err := dm.Translate(r); err != nil || r.StatusName != "" {
+		t.Fatalf("注册前不应翻译: %v %+v", err, r)
+	}
+	RegisterEnumOf("lateStatus", map[lateStatus]string{1: "启用"})
+	if err := Translate(r); err != nil || r.StatusName != "启用" {
+		t.Fatalf("注册后已缓存的结构体配置应失效: %v %+v", err, r)
+	}
+	r.StatusName = ""
+	if err := dm.Translate(r); err != nil || r.StatusName != "启用" {
+		t.Fatalf("其他实例的配置缓存也应失效: %v %+v", err, r)
+	}
+}
@@ -9,4 +9,8 @@ var (
 	ErrNotStruct = errors.New("dict-trans: value must be a struct")
 	// ErrNotSlice 不是切片类型
 	ErrNotSlice = errors.New("dict-trans: value must be a slice")
+	// ErrNilMap TranslateMap 的文档为 nil
+	ErrNilMap = errors.New("dict-trans: nil map")
+	// ErrNilSchema TranslateMap / TranslateJSON 的 schema 为 nil
+	ErrNilSchema = errors.New("dict-trans: nil map schema")
 )
@@ -0,0 +1,187 @@
+package dict
+
+import (
+	"fmt"
+	"math/bits"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// flagEnum 位掩码枚举：bits 升序，翻译时按它的顺序输出标签；match 按位数降序，组合值先于单个位匹配
+type flagEnum struct {
+	bits    []uint64
+	match   []uint64
+	labels  map[uint64]string
+	byLabel map[string]uint64
+}
+
+// flagRegistry 位掩码枚举注册表，与 EnumTranslator 一样写时复制
+type flagRegistry struct {
+	m  atomic.Pointer[map[string]*flagEnum]
+	mu sync.Mutex
+}
+
+var defaultFlagRegistry = &flagRegistry{}
+
+func (r *flagRegistry) get(name string) *flagEnum {
+	if m := r.m.Load(); m != nil {
+		return (*m)[name]
+	}
+	return nil
+}
+
+func (r *flagRegistry) register(name string, flags map[uint64]string) {
+	fe := &flagEnum{labels: make(map[uint64]string, len(flags)), byLabel: make(map[string]uint64, len(flags))}
+	for bit, label := range flags {
+		fe.labels[bit] = label
+		fe.byLabel[label] = bit
+		if bit != 0 {
+			fe.bits = append(fe.bits, bit)
+		}
+	}
+	sort.Slice(fe.bits, func(i, j int) bool { return fe.bits[i] < fe.bits[j] })
+	fe.match = append([]uint64(nil), fe.bits...)
+	sort.SliceStable(fe.match, func(i, j int) bool {
+		return bits.OnesCount64(fe.match[i]) > bits.OnesCount64(fe.match[j])
+	})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var old map[string]*flagEnum
+	if m := r.m.Load(); m != nil {
+		old = *m
+	}
+	next := make(map[string]*flagEnum, len(old)+1)
+	for k, v := range old {
+		next[k] = v
+	}
+	next[name] = fe
+	r.m.Store(&next)
+}
+
+// RegisterFlagEnum 注册位掩码枚举（权限、特性开关等）：key 是位值（1、2、4…），value 是标签。
+// 也可以注册多位的组合值，值里这些位全部置位时输出它的标签（代替其中各位的单独标签）；0 的标签只在值为 0 时使用。
+// 字段写 enum:"perm,flags" 即按位拆开翻译
+func RegisterFlagEnum(name string, flags map[uint64]string) {
+	defaultFlagRegistry.register(name, flags)
+}
+
+// ParseFlagEnum 把标签还原成掩码，与翻译互逆；未注册的枚举或标签返回错误
+func ParseFlagEnum(name string, labels ...string) (uint64, error) {
+	fe := defaultFlagRegistry.get(name)
+	if fe == nil {
+		return 0, fmt.Errorf("flag enum '%s' not found", name)
+	}
+	var mask uint64
+	for _, label := range labels {
+		bit, ok := fe.byLabel[strings.TrimSpace(label)]
+		if !ok {
+			return 0, fmt.Errorf("flag enum '%s': unknown label %q", name, label)
+		}
+		mask |= bit
+	}
+	return mask, nil
+}
+
+// flagTranslator enum 标签带 flags 选项时使用：
+//   - sep=  目标是 string 时标签的连接符，默认 "|"；目标是 []string 时逐个填入
+//   - unknown=  没有标签的剩余位输出成这个标签；不配置时输出十六进制值（如 0x40），unknown=- 丢弃
+//
+// 翻译结果先按 CompositeKey 拼好，写目标时再按目标类型展开（见 fieldConfig.flagSep）
+type flagTranslator struct {
+	name    string
+	unknown string
+}
+
+// parseFlagTag 解析 enum:"name,flags[,sep=|][,unknown=其他]"；不带 flags 选项返回 nil
+func parseFlagTag(tag string) (*flagTranslator, string) {
+	name, opts := splitTag(tag)
+	if _, ok := opts["flags"]; !ok || name == "" {
+		return nil, ""
+	}
+	sep, ok := opts["sep"]
+	if !ok || sep == "" {
+		sep = "|"
+	}
+	return &flagTranslator{name: name, unknown: opts["unknown"]}, sep
+}
+
+func (f *flagTranslator) Translate(value any, _ string, _ string) (string, error) {
+	fe := defaultFlagRegistry.get(f.name)
+	if fe == nil {
+		return "", fmt.Errorf("flag enum '%s' not found", f.name)
+	}
+	mask, err := flagMask(value)
+	if err != nil {
+		return "", err
+	}
+	if mask == 0 {
+		return fe.labels[0], nil
+	}
+	// 组合值先匹配，吃掉的位不再输出单个标签；输出仍按值升序
+	rest := mask
+	hit := make(map[uint64]bool, bits.OnesCount64(mask))
+	for _, bit := range fe.match {
+		if rest&bit == bit {
+			hit[bit] = true
+			rest &^= bit
+		}
+	}
+	labels := make([]string, 0, len(hit)+1)
+	for _, bit := range fe.bits {
+		if hit[bit] {
+			labels = append(labels, fe.labels[bit])
+		}
+	}
+	if rest != 0 {
+		switch f.unknown {
+		case "-":
+		case "":
+			labels = append(labels, "0x"+strconv.FormatUint(rest, 16))
+		default:
+			labels = append(labels, f.unknown)
+		}
+	}
+	return CompositeKey(labels...), nil
+}
+
+// flagMask 把源值转成掩码：整数直接用，字符串按十进制解析（空串为 0）
+func flagMask(value any) (uint64, error) {
+	switch v := value.(type) {
+	case int64:
+		return uint64(v), nil
+	case uint64:
+		return v, nil
+	case string:
+		if v == "" {
+			return 0, nil
+		}
+		return strconv.ParseUint(v, 10, 64)
+	}
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.CanInt():
+		return uint64(rv.Int()), nil
+	case rv.CanUint():
+		return rv.Uint(), nil
+	}
+	return 0, fmt.Errorf("unsupported flag value type: %T", value)
+}
+
+// setFlagTarget 写位掩码翻译结果：[]string 目标逐个填入，string 目标按 sep 连接
+func setFlagTarget(target reflect.Value, v, sep string, code any) {
+	labels := SplitCompositeKey(v)
+	if target.CanSet() && target.Kind() == reflect.Slice && target.Type().Elem().Kind() == reflect.String {
+		out := reflect.MakeSlice(target.Type(), len(labels), len(labels))
+		for i, l := range labels {
+			out.Index(i).SetString(l)
+		}
+		target.Set(out)
+		return
+	}
+	setTarget(target, strings.Join(labels, sep), code)
+}
@@ -0,0 +1,62 @@
+package dict
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFlagEnum(t *testing.T) {
+	RegisterFlagEnum("perm", map[uint64]string{0: "无", 1: "读", 2: "写", 4: "执行"})
+
+	type File struct {
+		Perm       int `enum:"perm,flags" dictField:"PermName"`
+		PermName   string
+		Perm2      uint8 `enum:"perm,flags,sep=、,unknown=其他" dictField:"PermLabels"`
+		PermLabels []string
+		Perm3      int64 `enum:"perm,flags,unknown=-" dictField:"Perm3Name"`
+		Perm3Name  string
+	}
+	f := &File{Perm: 5, Perm2: 3 | 64, Perm3: 2 | 128}
+	if err := Translate(f); err != nil {
+		t.Fatal(err)
+	}
+	if f.PermName != "读|执行" {
+		t.Fatalf("按位拆分失败: %q", f.PermName)
+	}
+	if !reflect.DeepEqual(f.PermLabels, []string{"读", "写", "其他"}) {
+		t.Fatalf("[]string 目标或 unknown 失败: %v", f.PermLabels)
+	}
+	if f.Perm3Name != "写" {
+		t.Fatalf("unknown=- 应丢弃未知位: %q", f.Perm3Name)
+	}
+
+	f = &File{Perm: 8 | 1}
+	if err := Translate(f); err != nil || f.PermName != "读|0x8" || !reflect.DeepEqual(f.PermLabels, []string{"无"}) {
+		t.Fatalf("默认未知位 / 0 值标签不对: %v %+v", err, f)
+	}
+
+	mask, err := ParseFlagEnum("perm", "读", "执行")
+	if err != nil || mask != 5 {
+		t.Fatalf("反向解析失败: %v %d", err, mask)
+	}
+	if _, err := ParseFlagEnum("perm", "删除"); err == nil {
+		t.Fatalf("未知标签应报错")
+	}
+	if _, err := ParseFlagEnum("nope"); err == nil {
+		t.Fatalf("未注册的枚举应报错")
+	}
+}
+
+func TestFlagEnumCombination(t *testing.T) {
+	RegisterFlagEnum("rw", map[uint64]string{1: "R", 2: "W", 3: "RW", 4: "X"})
+	type File struct {
+		Perm     int `enum:"rw,flags" dictField:"PermName"`
+		PermName string
+	}
+	for v, want := range map[int]string{3: "RW", 7: "RW|X", 5: "R|X", 2: "W"} {
+		f := &File{Perm: v}
+		if err := Translate(f); err != nil || f.PermName != want {
+			t.Fatalf("组合值应代替其中各位的标签: %d -> %q (%v)，期望 %q", v, f.PermName, err, want)
+		}
+	}
+}
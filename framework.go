@@ -31,8 +31,13 @@ func NewFramework(config *Config) *Framework {
 	}
 }
 
-// Init 初始化框架
+// Init 初始化框架（预加载用 context.Background()，租户隔离的字典表用 InitContext）
 func (f *Framework) Init() error {
+	return f.InitContext(context.Background())
+}
+
+// InitContext 初始化框架；ctx 传给预加载查询（ContextWithTenant 等），按租户过滤的字典表预加载该租户的数据
+func (f *Framework) InitContext(ctx context.Context) error {
 	// 初始化缓存：用户没给 CustomCache 时创建一个内存缓存挂在框架上（f.cache），
 	// 不写回 config——只有用户显式设置的 Config.Cache.CustomCache 才会被 DB 结果缓存使用，
 	// 否则三类 DB 缓存各自独立、ClearDBCache 不会波及 dictTable。
@@ -48,20 +53,78 @@ func (f *Framework) Init() error {
 	}
 
 	// 预加载字典：要求已注册的字典表翻译器实现 DictTableLoader（CreateDictTableTranslatorFromDB 的返回值已实现），
-	// 整表读入 preloader 并预热结果缓存
+	// 整表读入 preloader 并预热结果缓存；PreloadDicts 含 "*" 时改用 DictTableBulkLoader 一次查询取全部类型，
+	// 其余具名类型照常逐个加载
+	loaded := make(map[string]bool)
+	if preloadAll(f.config.Performance.PreloadDicts) {
+		all, err := f.preloadAllTypes(ctx)
+		if err != nil {
+			return fmt.Errorf("预加载全部字典失败: %w", err)
+		}
+		for dictType, data := range all {
+			data := data
+			_ = f.preloader.Preload(dictType, func() (map[string]string, error) { return data, nil })
+			loaded[dictType] = true
+		}
+	}
 	for _, dictType := range f.config.Performance.PreloadDicts {
 		dt := dictType
+		if dt == "*" || loaded[dt] {
+			continue
+		}
 		err := f.preloader.Preload(dt, func() (map[string]string, error) {
-			return defaultDictTableManager.preload(context.Background(), []string{dt})
+			return defaultDictTableManager.preload(ctx, []string{dt})
 		})
 		if err != nil {
 			return fmt.Errorf("预加载字典 %s 失败: %w", dt, err)
 		}
+		loaded[dt] = true
 	}
 
 	return nil
 }
 
+// preloadAllTypes "*" 的全量预加载：单表后端已注册时必须实现 DictTableBulkLoader；双表后端实现了它时一并加载
+// （同名类型以单表为准）。两类后端都没有可用的全量加载时返回错误
+func (f *Framework) preloadAllTypes(ctx context.Context) (map[string]map[string]string, error) {
+	var all map[string]map[string]string
+	if defaultDictTableManager.backend.Load() != nil {
+		data, err := defaultDictTableManager.preloadAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+		all = data
+	}
+	if b := defaultDictTableTwoManager.backend.Load(); b != nil && b.all != nil {
+		data, err := defaultDictTableTwoManager.preloadAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if all == nil {
+			all = make(map[string]map[string]string, len(data))
+		}
+		for dictType, kv := range data {
+			if _, ok := all[dictType]; !ok {
+				all[dictType] = kv
+			}
+		}
+	}
+	if all == nil {
+		return defaultDictTableManager.preloadAll(ctx) // 都不可用：返回"未注册 / 未实现"的错误
+	}
+	return all, nil
+}
+
+// preloadAll PreloadDicts 是否要求预加载全部字典类型（含 "*"）
+func preloadAll(dicts []string) bool {
+	for _, d := range dicts {
+		if d == "*" {
+			return true
+		}
+	}
+	return false
+}
+
 // Preloaded 读取预加载的数据（Init 时按 Config.Performance.PreloadDicts 加载）
 func (f *Framework) Preloaded(dictType, key string) (string, bool) {
 	return f.preloader.Get(dictType, key)
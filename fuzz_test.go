@@ -8,6 +8,7 @@ func FuzzParseDBTag(f *testing.F) {
 	for _, seed := range []string{
 		"", "user:id:name", "table=user,key=id,value=name", "table=user,key=id",
 		"a:b", "a:b:c:d", "table=,key=,value=", ":::", "table=t,key=k,value=v,extra=x", "t:k:v=1",
+		"table=t,key=a+b,value=v,from=A+B", "table=t,key=a+b,value=v",
 	} {
 		f.Add(seed)
 	}
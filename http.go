@@ -0,0 +1,216 @@
+package dict
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type localeCtxKey struct{}
+type tenantCtxKey struct{}
+
+// ContextWithLocale 在 ctx 上挂语言（如 "zh-CN"）；Handler 按 Accept-Language 自动设置。
+// 多语言字典表可在 ContextConditions 里用 LocaleCondition 按它过滤
+func ContextWithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeCtxKey{}, locale)
+}
+
+// Locale 取出 ctx 上的语言
+func Locale(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	l, ok := ctx.Value(localeCtxKey{}).(string)
+	return l, ok
+}
+
+// ContextWithTenant 在 ctx 上挂租户；Handler 用 WithTenant 配置的提取函数自动设置
+func ContextWithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantCtxKey{}, tenant)
+}
+
+// Tenant 取出 ctx 上的租户
+func Tenant(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	t, ok := ctx.Value(tenantCtxKey{}).(string)
+	return t, ok
+}
+
+// LocaleCondition 按 ctx 上的语言过滤的 ContextCondition（没有语言时取 nil，查不到数据）
+func LocaleCondition(field string) ContextCondition {
+	return ContextCondition{Field: field, Value: func(ctx context.Context) any {
+		if l, ok := Locale(ctx); ok {
+			return l
+		}
+		return nil
+	}}
+}
+
+// TenantCondition 按 ctx 上的租户过滤的 ContextCondition（没有租户时取 nil，查不到数据）
+func TenantCondition(field string) ContextCondition {
+	return ContextCondition{Field: field, Value: func(ctx context.Context) any {
+		if t, ok := Tenant(ctx); ok {
+			return t
+		}
+		return nil
+	}}
+}
+
+// HTTPOption Handler / WriteJSON 的选项
+type HTTPOption func(*httpOpts)
+
+type httpOpts struct {
+	dm      *DictManager
+	tenant  func(r *http.Request) string
+	onError func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// WithTenant 从请求里取租户（如 Header、JWT），挂到请求 ctx 上；返回 "" 表示没有租户
+func WithTenant(fn func(r *http.Request) string) HTTPOption {
+	return func(o *httpOpts) { o.tenant = fn }
+}
+
+// WithErrorHandler 翻译或序列化失败时的处理（默认 DefaultHTTPErrorHandler）
+func WithErrorHandler(fn func(w http.ResponseWriter, r *http.Request, err error)) HTTPOption {
+	return func(o *httpOpts) { o.onError = fn }
+}
+
+// WithHTTPManager 用指定的 DictManager 翻译（默认全局实例）
+func WithHTTPManager(dm *DictManager) HTTPOption {
+	return func(o *httpOpts) { o.dm = dm }
+}
+
+// DefaultHTTPErrorHandler 默认错误处理：请求已取消（客户端断开 / 超时）时不写响应，
+// 其余写 500 与 {"error":"..."}
+func DefaultHTTPErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusInternalServerError)
+	var buf bytes.Buffer
+	buf.WriteString(`{"error":`)
+	writeJSONString(&buf, err.Error())
+	buf.WriteString("}\n")
+	_, _ = w.Write(buf.Bytes())
+}
+
+type httpOptsKey struct{}
+
+func buildHTTPOpts(opts []HTTPOption) *httpOpts {
+	o := &httpOpts{dm: defaultManager, onError: DefaultHTTPErrorHandler}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// Handler 包一层 http.Handler：按 Accept-Language 设置语言、按 WithTenant 设置租户，
+// 并把选项挂到请求 ctx 上，handler 里用 WriteJSON 写响应即按这些设置翻译
+func Handler(next http.Handler, opts ...HTTPOption) http.Handler {
+	o := buildHTTPOpts(opts)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), httpOptsKey{}, o)
+		if l := parseAcceptLanguage(r.Header.Get("Accept-Language")); l != "" {
+			ctx = ContextWithLocale(ctx, l)
+		}
+		if o.tenant != nil {
+			if t := o.tenant(r); t != "" {
+				ctx = ContextWithTenant(ctx, t)
+			}
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// WriteJSON 用请求 ctx 翻译 v（取消、语言、租户；包装类型经 RegisterUnWrapper 注册的解包器解包）后写 200 JSON 响应。
+// 非指针的 v 先拷贝一份再翻译；出错时交给错误处理（Handler 的 WithErrorHandler，默认 DefaultHTTPErrorHandler）并返回错误。
+// 不经过 Handler 时按默认设置，opts 可覆盖
+func WriteJSON(w http.ResponseWriter, r *http.Request, v any, opts ...HTTPOption) error {
+	o, _ := r.Context().Value(httpOptsKey{}).(*httpOpts)
+	if o == nil || len(opts) > 0 {
+		base := buildHTTPOpts(nil)
+		if o != nil {
+			*base = *o
+		}
+		for _, opt := range opts {
+			opt(base)
+		}
+		o = base
+	}
+	if err := o.write(w, r, v); err != nil {
+		o.onError(w, r, err)
+		return err
+	}
+	return nil
+}
+
+func (o *httpOpts) write(w http.ResponseWriter, r *http.Request, v any) error {
+	ctx := r.Context()
+	if v != nil {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Ptr {
+			p := reflect.New(rv.Type())
+			p.Elem().Set(rv)
+			rv = p
+		}
+		if !rv.IsNil() {
+			err := o.dm.TranslateWith(rv.Interface(), WithContext(ctx))
+			if err != nil && !errors.Is(err, ErrNotStruct) {
+				return err
+			}
+			v = rv.Interface()
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(v); err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// parseAcceptLanguage 取 Accept-Language 里 q 值最高的语言（"zh-CN,zh;q=0.9,en;q=0.8" -> "zh-CN"），"*" 不算
+func parseAcceptLanguage(h string) string {
+	type lang struct {
+		tag string
+		q   float64
+	}
+	var langs []lang
+	for _, part := range strings.Split(h, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		if k, v, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(k) == "q" {
+			f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				continue
+			}
+			q = f
+		}
+		if q > 0 {
+			langs = append(langs, lang{tag, q})
+		}
+	}
+	if len(langs) == 0 {
+		return ""
+	}
+	sort.SliceStable(langs, func(i, j int) bool { return langs[i].q > langs[j].q })
+	return langs[0].tag
+}
@@ -0,0 +1,125 @@
+package dict
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type httpItem struct {
+	Sex     string `json:"sex" dict:"sex" dictField:"SexName"`
+	SexName string `json:"sexName"`
+}
+
+type httpResult struct {
+	Code int `json:"code"`
+	Data any `json:"data"`
+}
+
+func TestHandlerWriteJSON(t *testing.T) {
+	RegisterDict("sex", map[string]string{"1": "男", "2": "女"})
+	RegisterUnWrapper(UnWrapperFunc(func(v any) (any, error) {
+		if r, ok := v.(*httpResult); ok {
+			return r.Data, nil
+		}
+		return nil, nil
+	}))
+
+	var locale, tenant string
+	h := Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale, _ = Locale(r.Context())
+		tenant, _ = Tenant(r.Context())
+		_ = WriteJSON(w, r, httpResult{Data: []httpItem{{Sex: "1"}, {Sex: "2"}}})
+	}), WithTenant(func(r *http.Request) string { return r.Header.Get("X-Tenant") }))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "en;q=0.5, zh-CN, *;q=0.1")
+	req.Header.Set("X-Tenant", "t1")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	want := `{"code":0,"data":[{"sex":"1","sexName":"男"},{"sex":"2","sexName":"女"}]}` + "\n"
+	if rec.Code != http.StatusOK || rec.Body.String() != want {
+		t.Fatalf("响应不对: %d %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Fatalf("Content-Type 不对: %s", ct)
+	}
+	if locale != "zh-CN" || tenant != "t1" {
+		t.Fatalf("语言 / 租户不对: %q %q", locale, tenant)
+	}
+}
+
+// WithHTTPManager：用该实例的解包器；解包器返回结构体值时翻译包装里的数据而不是报 ErrNotPointer
+func TestWriteJSONManagerUnwrapStruct(t *testing.T) {
+	dm := NewDictManager()
+	dm.RegisterDict("sex", map[string]string{"1": "男"})
+	dm.RegisterUnWrapper(UnWrapperFunc(func(v any) (any, error) {
+		if r, ok := v.(*httpResult); ok {
+			return r.Data, nil
+		}
+		return nil, nil
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	if err := WriteJSON(rec, req, httpResult{Data: httpItem{Sex: "1"}}, WithHTTPManager(dm)); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"code":0,"data":{"sex":"1","sexName":"男"}}` + "\n"
+	if rec.Code != http.StatusOK || rec.Body.String() != want {
+		t.Fatalf("响应不对: %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestWriteJSONErrors(t *testing.T) {
+	RegisterDict("sex", map[string]string{"1": "男"})
+
+	// 请求已取消：不写响应，返回错误
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	if err := WriteJSON(rec, req, &httpItem{Sex: "1"}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("应返回取消错误: %v", err)
+	}
+	if rec.Body.Len() != 0 {
+		t.Fatalf("已取消的请求不应写响应: %s", rec.Body.String())
+	}
+
+	// 序列化失败：交给自定义错误处理
+	var got error
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	rec = httptest.NewRecorder()
+	err := WriteJSON(rec, req, map[string]any{"f": func() {}}, WithErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+		got = err
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	if err == nil || got != err || rec.Code != http.StatusTeapot {
+		t.Fatalf("错误处理未生效: %v %v %d", err, got, rec.Code)
+	}
+
+	// 默认错误处理：500 + {"error":...}
+	rec = httptest.NewRecorder()
+	_ = WriteJSON(rec, req, map[string]any{"f": func() {}})
+	if rec.Code != http.StatusInternalServerError || !strings.HasPrefix(rec.Body.String(), `{"error":`) {
+		t.Fatalf("默认错误处理不对: %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	cases := map[string]string{
+		"":                          "",
+		"*":                         "",
+		"fr-CH, fr;q=0.9, en;q=0.8": "fr-CH",
+		"en;q=0.3, de;q=0.7":        "de",
+		"en;q=0":                    "",
+	}
+	for h, want := range cases {
+		if got := parseAcceptLanguage(h); got != want {
+			t.Fatalf("%q 期望 %q，实际 %q", h, want, got)
+		}
+	}
+}
@@ -0,0 +1,464 @@
+package dict
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// TranslateInto 把 src 映射到 dst 并翻译（实体 -> DTO）：目标类型上的标签引用源字段，
+//
+//	type OrderDTO struct {
+//	    ID         int64
+//	    Status     int
+//	    StatusName string     `from:"Status" dict:"status"`
+//	    UserName   string     `from:"UserID" db:"table=user,key=id,value=name"`
+//	    City       string     `from:"Address.City"`
+//	    Items      []ItemDTO  // 按同名字段逐个映射
+//	}
+//
+// 带翻译标签（dict、enum、range、translate、db、chain、tree、dictTable、dictTableTwo，写法同结构体标签）的目标字段
+// 写入源字段的译文；其余导出字段从源字段复制（默认同名，from 可写 "A.B" 路径，from:"-" 跳过），
+// 类型不同的结构体、指针、切片、map 递归映射，同一源指针只映射一次（共享与成环关系不变）。
+// 计划按（源类型, 目标类型）编译一次并缓存；DB 类来源先收集全部 key，够阈值时按分组批量预取。
+// 标签选项 miss=nil 在源值为空或查不到时把目标清零；RegisterTypeMapping 登记在目标类型上的映射同样生效
+// （Field 照常复制，译文写入 Target，Target 只能是一个字段）。
+// dst 必须是非 nil 指针；from 写了不存在的字段时返回错误
+func TranslateInto(ctx context.Context, src, dst any) error {
+	return defaultManager.TranslateInto(ctx, src, dst)
+}
+
+// TranslateInto 映射并翻译（实例方法）
+func (dm *DictManager) TranslateInto(ctx context.Context, src, dst any) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() {
+		return ErrNotPointer
+	}
+	sv := reflect.ValueOf(src)
+	if !sv.IsValid() {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	dv = dv.Elem()
+
+	// 收集：DB 类来源的 key 先攒起来，够阈值就批量预取（映射结果写进临时值，丢弃）
+	lookup, err := dm.intoMayLookup(sv.Type(), dv.Type())
+	if err != nil {
+		return err
+	}
+	if lookup {
+		c := &intoRun{dm: dm, ctx: ctx, collect: newCollectWalk(ctx), seen: make(map[intoSeenKey]reflect.Value)}
+		if err := c.assign(sv, reflect.New(dv.Type()).Elem()); err != nil {
+			return err
+		}
+		if c.n > 0 && c.n >= GetConfig().Performance.BatchQueryThreshold {
+			if err := c.collect.flush(); err != nil {
+				return err
+			}
+		}
+	}
+	r := &intoRun{dm: dm, ctx: ctx, seen: make(map[intoSeenKey]reflect.Value)}
+	return r.assign(sv, dv)
+}
+
+type intoKey struct{ src, dst reflect.Type }
+
+// intoPlan 一对（源, 目标）结构体类型的映射计划
+type intoPlan struct {
+	steps     []intoStep
+	mayLookup bool // 自身有 DB 类来源，或嵌套映射里有
+	err       error
+}
+
+// intoStep 一个目标字段：tr 非 nil 时写源值的译文，否则复制源值
+type intoStep struct {
+	dst     int
+	src     []int // 源字段下标路径（from:"A.B"），途中的指针自动解引用
+	tr      Translator
+	tag     string
+	flagSep string
+	missNil bool // miss=nil：源值为空或查不到时把目标清零
+}
+
+// intoPlanFor 取（源, 目标）的计划，没有就编译（连同嵌套的结构体对）并缓存
+func (dm *DictManager) intoPlanFor(st, dt reflect.Type) *intoPlan {
+	k := intoKey{st, dt}
+	dm.configMutex.RLock()
+	p, ok := dm.intoCache[k]
+	dm.configMutex.RUnlock()
+	if ok {
+		return p
+	}
+	building := make(map[intoKey]*intoPlan)
+	p = dm.compileInto(st, dt, building)
+	dm.configMutex.Lock()
+	if dm.intoCache == nil {
+		dm.intoCache = make(map[intoKey]*intoPlan)
+	}
+	for bk, bp := range building {
+		dm.intoCache[bk] = bp
+	}
+	dm.configMutex.Unlock()
+	return p
+}
+
+// compileInto 编译计划；building 里是本轮正在编译的结构体对（递归类型遇到自己时直接返回半成品）
+func (dm *DictManager) compileInto(st, dt reflect.Type, building map[intoKey]*intoPlan) *intoPlan {
+	k := intoKey{st, dt}
+	if p, ok := building[k]; ok {
+		return p
+	}
+	dm.configMutex.RLock()
+	p, ok := dm.intoCache[k]
+	dm.configMutex.RUnlock()
+	if ok {
+		return p
+	}
+	p = &intoPlan{}
+	building[k] = p
+
+	// RegisterTypeMapping 登记在目标类型上的映射：字段本身照常复制，译文写进映射的目标字段
+	mappings := dm.loadReg().mappings[dt]
+	for i := 0; i < dt.NumField(); i++ {
+		df := dt.Field(i)
+		from, explicit := df.Tag.Lookup("from")
+		if !df.IsExported() || from == "-" {
+			continue
+		}
+		if !explicit {
+			from = df.Name
+		}
+		path, srcType, ok := resolveFromPath(st, from)
+		if !ok {
+			if explicit {
+				p.err = fmt.Errorf("dict-trans: TranslateInto: %s has no field %q (%s.%s)", st, from, dt, df.Name)
+				return p
+			}
+			continue
+		}
+		step := intoStep{dst: i, src: path}
+		if m, mapped := mappings[df.Name]; mapped {
+			// 映射覆盖字段上的翻译标签：字段本身按普通字段复制
+			if m.Target != "" {
+				target := fieldIndexByName(dt, m.Target)
+				if strings.Contains(m.Target, ",") || target < 0 {
+					p.err = fmt.Errorf("dict-trans: TranslateInto: mapping %s.%s: target %q must be one field", dt, df.Name, m.Target)
+					return p
+				}
+				ms := intoStep{dst: target, src: path}
+				dm.intoTranslator(&ms, m.tag())
+				if ms.tr != nil {
+					p.steps = append(p.steps, ms)
+					p.mayLookup = p.mayLookup || isLookup(ms.tr)
+				}
+			}
+		} else if dm.intoTranslator(&step, df.Tag) && step.tr == nil {
+			continue
+		}
+		if step.tr != nil {
+			p.mayLookup = p.mayLookup || isLookup(step.tr)
+		} else if ss, ds, ok := structPair(srcType, df.Type); ok && ss != ds {
+			np := dm.compileInto(ss, ds, building)
+			if np.err != nil {
+				p.err = np.err
+				return p
+			}
+			p.mayLookup = p.mayLookup || np.mayLookup
+		}
+		p.steps = append(p.steps, step)
+	}
+	return p
+}
+
+func isLookup(tr Translator) bool {
+	switch tr.(type) {
+	case *lookupTranslator, prefetcher:
+		return true
+	}
+	return false
+}
+
+// intoMayLookup 顶层（可能是切片 / map / 指针）对应的结构体对是否要走收集预取
+func (dm *DictManager) intoMayLookup(st, dt reflect.Type) (bool, error) {
+	ss, ds, ok := structPair(st, dt)
+	if !ok || ss == ds {
+		return false, nil
+	}
+	p := dm.intoPlanFor(ss, ds)
+	return p.mayLookup, p.err
+}
+
+// structPair 解开指针、切片、数组、map 值后，源与目标是否都是结构体
+func structPair(st, dt reflect.Type) (reflect.Type, reflect.Type, bool) {
+	for i := 0; i < maxHoldDepth; i++ {
+		for st.Kind() == reflect.Ptr {
+			st = st.Elem()
+		}
+		for dt.Kind() == reflect.Ptr {
+			dt = dt.Elem()
+		}
+		if st.Kind() == reflect.Struct && dt.Kind() == reflect.Struct {
+			return st, dt, true
+		}
+		switch {
+		case (st.Kind() == reflect.Slice || st.Kind() == reflect.Array) && (dt.Kind() == reflect.Slice || dt.Kind() == reflect.Array),
+			st.Kind() == reflect.Map && dt.Kind() == reflect.Map:
+			st, dt = st.Elem(), dt.Elem()
+		default:
+			return nil, nil, false
+		}
+	}
+	return nil, nil, false
+}
+
+// resolveFromPath 按 "A.B" 找源字段下标路径（途中的指针解引用），返回末端字段类型
+func resolveFromPath(st reflect.Type, from string) ([]int, reflect.Type, bool) {
+	var path []int
+	t := st
+	for _, name := range strings.Split(from, ".") {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return nil, nil, false
+		}
+		j := fieldIndexByName(t, strings.TrimSpace(name))
+		if j < 0 || !t.Field(j).IsExported() {
+			return nil, nil, false
+		}
+		path = append(path, j)
+		t = t.Field(j).Type
+	}
+	return path, t, true
+}
+
+// intoTranslator 按目标字段的翻译标签填好 step 的翻译器（优先级同结构体标签；switch 依赖源结构体的兄弟字段，这里不支持）。
+// 有翻译标签时返回 true（翻译器未注册时 step.tr 为 nil，字段跳过，不当普通字段复制）
+func (dm *DictManager) intoTranslator(step *intoStep, tag reflect.StructTag) bool {
+	for _, kind := range []string{"translate", "chain", "tree", "db", "dictTableTwo", "dictTable", "enum", "range", "dict"} {
+		v := tag.Get(kind)
+		if v == "" {
+			continue
+		}
+		name, opts := splitTag(v)
+		step.missNil = opts["miss"] == "nil"
+		switch kind {
+		case "translate":
+			if t := dm.loadReg().translators[name]; t != nil {
+				step.tr, step.tag = t, v
+			}
+		case "chain":
+			if c := dm.parseChainTag(v); c != nil {
+				step.tr, step.tag = c, v
+			}
+		case "tree":
+			if t := dm.parseTreeTag(v); t != nil {
+				step.tr, step.tag = t, v
+			}
+		case "db":
+			// 目标只有一个字段：多列（value=a+b）不支持
+			if spec, ok := parseDBSpec(v); ok && spec.from == nil && spec.targetsMatch("") {
+				step.tr, step.tag = createDBTranslator(spec.table, spec.keyField, spec.valueField), v
+			}
+		default:
+			if kind == "enum" {
+				if ft, sep := parseFlagTag(v); ft != nil {
+					step.tr, step.tag, step.flagSep = ft, v, sep
+					return true
+				}
+			}
+			if st, ok := dm.parseStep(kind + ":" + name); ok {
+				step.tr, step.tag = st.tr, st.tag
+			}
+		}
+		return true
+	}
+	return false
+}
+
+type intoSeenKey struct {
+	addr     uintptr
+	src, dst reflect.Type
+}
+
+// intoRun 一次 TranslateInto 的状态；collect 非 nil 时只收集 DB 类 key
+type intoRun struct {
+	dm      *DictManager
+	ctx     context.Context
+	collect *walk
+	n       int
+	seen    map[intoSeenKey]reflect.Value // 源指针 -> 已映射出的目标指针
+}
+
+// assign 把源值映射到目标值：可赋值直接赋值，结构体按计划，指针 / 切片 / 数组 / map 逐层，标量按可转换类型转换
+func (r *intoRun) assign(sv, dv reflect.Value) error {
+	if sv.Kind() == reflect.Interface {
+		if sv.IsNil() {
+			return nil
+		}
+		sv = sv.Elem()
+	}
+	st, dt := sv.Type(), dv.Type()
+	if st.AssignableTo(dt) {
+		dv.Set(sv)
+		return nil
+	}
+	switch {
+	case sv.Kind() == reflect.Ptr:
+		if sv.IsNil() {
+			return nil
+		}
+		if dv.Kind() != reflect.Ptr {
+			return r.assign(sv.Elem(), dv)
+		}
+		key := intoSeenKey{addr: sv.Pointer(), src: st, dst: dt}
+		if p, ok := r.seen[key]; ok {
+			dv.Set(p)
+			return nil
+		}
+		p := reflect.New(dt.Elem())
+		r.seen[key] = p
+		if err := r.assign(sv.Elem(), p.Elem()); err != nil {
+			return err
+		}
+		dv.Set(p)
+	case dv.Kind() == reflect.Ptr:
+		p := reflect.New(dt.Elem())
+		if err := r.assign(sv, p.Elem()); err != nil {
+			return err
+		}
+		dv.Set(p)
+	case sv.Kind() == reflect.Struct && dv.Kind() == reflect.Struct:
+		return r.structInto(sv, dv)
+	case (sv.Kind() == reflect.Slice || sv.Kind() == reflect.Array) && dv.Kind() == reflect.Slice:
+		if sv.Kind() == reflect.Slice && sv.IsNil() {
+			return nil
+		}
+		out := reflect.MakeSlice(dt, sv.Len(), sv.Len())
+		for i := 0; i < sv.Len(); i++ {
+			if err := r.assign(sv.Index(i), out.Index(i)); err != nil {
+				return err
+			}
+		}
+		dv.Set(out)
+	case (sv.Kind() == reflect.Slice || sv.Kind() == reflect.Array) && dv.Kind() == reflect.Array:
+		for i := 0; i < sv.Len() && i < dv.Len(); i++ {
+			if err := r.assign(sv.Index(i), dv.Index(i)); err != nil {
+				return err
+			}
+		}
+	case sv.Kind() == reflect.Map && dv.Kind() == reflect.Map:
+		if sv.IsNil() || !st.Key().ConvertibleTo(dt.Key()) {
+			return nil
+		}
+		out := reflect.MakeMapWithSize(dt, sv.Len())
+		iter := sv.MapRange()
+		for iter.Next() {
+			nv := reflect.New(dt.Elem()).Elem()
+			if err := r.assign(iter.Value(), nv); err != nil {
+				return err
+			}
+			out.SetMapIndex(iter.Key().Convert(dt.Key()), nv)
+		}
+		dv.Set(out)
+	case convertibleScalar(st, dt):
+		dv.Set(sv.Convert(dt))
+	}
+	return nil
+}
+
+// convertibleScalar 标量之间的安全转换：数值互转、字符串 / 命名字符串互转、布尔；整数 -> 字符串（按 rune 转）不算
+func convertibleScalar(st, dt reflect.Type) bool {
+	if !st.ConvertibleTo(dt) {
+		return false
+	}
+	sk, dk := st.Kind(), dt.Kind()
+	switch {
+	case sk == reflect.String && dk == reflect.String, sk == reflect.Bool && dk == reflect.Bool:
+		return true
+	case isNumberKind(sk) && isNumberKind(dk):
+		return true
+	}
+	return false
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	return (k >= reflect.Int && k <= reflect.Uint64) || k == reflect.Float32 || k == reflect.Float64
+}
+
+func (r *intoRun) structInto(sv, dv reflect.Value) error {
+	if err := r.ctx.Err(); err != nil {
+		return err
+	}
+	p := r.dm.intoPlanFor(sv.Type(), dv.Type())
+	if p.err != nil {
+		return p.err
+	}
+	for i := range p.steps {
+		step := &p.steps[i]
+		fv, ok := fieldByPath(sv, step.src)
+		if !ok {
+			continue
+		}
+		if step.tr == nil {
+			if err := r.assign(fv, dv.Field(step.dst)); err != nil {
+				return err
+			}
+			continue
+		}
+		key, ok := sourceKey(fv)
+		if !ok || key == "" {
+			if step.missNil && r.collect == nil {
+				clearTarget(dv.Field(step.dst))
+			}
+			continue
+		}
+		if r.collect != nil {
+			r.collect.add(r.ctx, step.tr, key)
+			r.n++
+			continue
+		}
+		var label string
+		var err error
+		if ct, ok := step.tr.(ContextTranslator); ok {
+			label, err = ct.TranslateContext(r.ctx, key, dv.Type().Field(step.dst).Name, step.tag)
+		} else {
+			label, err = step.tr.Translate(key, dv.Type().Field(step.dst).Name, step.tag)
+		}
+		if err != nil {
+			return err
+		}
+		if label == "" {
+			if step.missNil {
+				clearTarget(dv.Field(step.dst))
+			}
+			continue
+		}
+		if l, suffix, disabled := splitDisabled(label); disabled {
+			label = l + suffix
+		}
+		if step.flagSep != "" {
+			setFlagTarget(dv.Field(step.dst), label, step.flagSep, key)
+		} else {
+			setTarget(dv.Field(step.dst), label, key)
+		}
+	}
+	return nil
+}
+
+// fieldByPath 按下标路径取源字段，途中的 nil 指针返回 false
+func fieldByPath(v reflect.Value, path []int) (reflect.Value, bool) {
+	for _, i := range path {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v, true
+}
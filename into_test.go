@@ -0,0 +1,168 @@
+package dict
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+type intoAddress struct {
+	City string
+}
+
+type intoItem struct {
+	Type string
+	Dept string
+}
+
+type intoOrder struct {
+	ID      int64
+	Status  string
+	Dept    string
+	Count   int32
+	Address *intoAddress
+	Items   []intoItem
+	Owner   *intoItem
+	Backup  *intoItem
+	Secret  string
+}
+
+type intoItemDTO struct {
+	Type     string
+	TypeName string `from:"Type" dict:"sex"`
+	DeptName string `from:"Dept" db:"table=dept,key=id,value=name"`
+}
+
+type intoOrderDTO struct {
+	ID         int64
+	StatusName string `from:"Status" dict:"sex"`
+	DeptName   string `from:"Dept" db:"table=dept,key=id,value=name"`
+	Count      int64
+	City       string `from:"Address.City"`
+	Items      []intoItemDTO
+	Owner      *intoItemDTO
+	Backup     *intoItemDTO
+	Secret     string `from:"-"`
+	Missing    string
+}
+
+func TestTranslateInto(t *testing.T) {
+	RegisterDict("sex", map[string]string{"1": "男", "2": "女"})
+	EnableDBCache(true)
+	ClearDBCache()
+	t.Cleanup(ClearDBCache)
+	RegisterDBTranslator(&deptDB{})
+
+	owner := &intoItem{Type: "2", Dept: "d1"}
+	src := intoOrder{
+		ID: 7, Status: "1", Dept: "d2", Count: 3, Secret: "x",
+		Address: &intoAddress{City: "杭州"},
+		Items:   []intoItem{{Type: "1", Dept: "d4"}, {Type: "2"}},
+		Owner:   owner, Backup: owner,
+	}
+	var dst intoOrderDTO
+	if err := TranslateInto(context.Background(), src, &dst); err != nil {
+		t.Fatal(err)
+	}
+	if dst.ID != 7 || dst.Count != 3 || dst.City != "杭州" || dst.Secret != "" {
+		t.Fatalf("字段复制不对: %+v", dst)
+	}
+	if dst.StatusName != "男" || dst.DeptName != "研发部" {
+		t.Fatalf("顶层翻译不对: %+v", dst)
+	}
+	if len(dst.Items) != 2 || dst.Items[0].TypeName != "男" || dst.Items[0].DeptName != "后端组" ||
+		dst.Items[1].Type != "2" || dst.Items[1].TypeName != "女" {
+		t.Fatalf("切片映射不对: %+v", dst.Items)
+	}
+	if dst.Owner == nil || dst.Owner.TypeName != "女" || dst.Owner.DeptName != "总公司" || dst.Owner != dst.Backup {
+		t.Fatalf("共享指针应映射为同一个目标: %+v %+v", dst.Owner, dst.Backup)
+	}
+
+	// 源为指针、nil 嵌套字段跳过
+	var dst2 intoOrderDTO
+	if err := TranslateInto(context.Background(), &intoOrder{Status: "2"}, &dst2); err != nil {
+		t.Fatal(err)
+	}
+	if dst2.StatusName != "女" || dst2.City != "" || dst2.Owner != nil || dst2.Items != nil {
+		t.Fatalf("nil 字段应跳过: %+v", dst2)
+	}
+}
+
+func TestTranslateIntoSliceBatch(t *testing.T) {
+	RegisterDict("sex", map[string]string{"1": "男", "2": "女"})
+	EnableDBCache(true)
+	ClearDBCache()
+	t.Cleanup(ClearDBCache)
+	be := &deptDB{}
+	RegisterDBTranslator(be)
+
+	src := make([]*intoOrder, 30)
+	for i := range src {
+		src[i] = &intoOrder{Status: "1", Dept: "d2", Items: []intoItem{{Dept: "d4"}}}
+	}
+	var dst []intoOrderDTO
+	if err := TranslateInto(context.Background(), src, &dst); err != nil {
+		t.Fatal(err)
+	}
+	if len(dst) != 30 || dst[29].DeptName != "研发部" || dst[29].Items[0].DeptName != "后端组" {
+		t.Fatalf("切片映射不对: %d %+v", len(dst), dst[29])
+	}
+	if be.single != 0 || be.batch == 0 {
+		t.Fatalf("应批量预取而非逐条查询: single=%d batch=%d", atomic.LoadInt64(&be.single), be.batch)
+	}
+}
+
+func TestTranslateIntoErrors(t *testing.T) {
+	var dto intoOrderDTO
+	if err := TranslateInto(context.Background(), intoOrder{}, dto); !errors.Is(err, ErrNotPointer) {
+		t.Fatalf("dst 非指针应返回 ErrNotPointer: %v", err)
+	}
+	type Bad struct {
+		Name string `from:"Nope"`
+	}
+	var bad Bad
+	if err := TranslateInto(context.Background(), intoOrder{}, &bad); err == nil || !strings.Contains(err.Error(), "Nope") {
+		t.Fatalf("from 字段不存在应返回错误: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := TranslateInto(ctx, intoOrder{}, &dto); !errors.Is(err, context.Canceled) {
+		t.Fatalf("ctx 已取消应返回错误: %v", err)
+	}
+}
+
+type intoMappedDTO struct {
+	Status     string
+	StatusName string
+	Dept       *string
+	DeptName   *string `from:"Dept" dict:"intoDept,miss=nil"`
+	OwnerName  string  `from:"Dept" translate:"intoNeverRegistered"`
+}
+
+func TestTranslateIntoMappingAndMiss(t *testing.T) {
+	dm := NewDictManager()
+	dm.RegisterDict("sex", map[string]string{"1": "男", "2": "女"})
+	dm.RegisterDict("intoDept", map[string]string{"d1": "总公司"})
+	ForManager[intoMappedDTO](dm).Field("Status").Dict("sex").Into("StatusName")
+
+	old := "旧值"
+	dst := intoMappedDTO{DeptName: &old}
+	if err := dm.TranslateInto(context.Background(), &intoOrder{Status: "2", Dept: "d9"}, &dst); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Status != "2" || dst.StatusName != "女" {
+		t.Fatalf("目标类型上的映射应生效: %+v", dst)
+	}
+	if dst.Dept == nil || *dst.Dept != "d9" || dst.DeptName != nil {
+		t.Fatalf("miss=nil 查不到时应清零目标: %+v", dst)
+	}
+	if dst.OwnerName != "" {
+		t.Fatalf("翻译器未注册的字段应跳过，不当普通字段复制: %q", dst.OwnerName)
+	}
+
+	if err := dm.TranslateInto(context.Background(), &intoOrder{Dept: "d1"}, &dst); err != nil || dst.DeptName == nil || *dst.DeptName != "总公司" {
+		t.Fatalf("指针目标应写入译文: %v %+v", err, dst)
+	}
+}
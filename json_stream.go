@@ -0,0 +1,373 @@
+package dict
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DefaultJSONWindow TranslateJSON 默认的窗口大小：攒够这么多待翻译的值就批量查一次并把这段输出写出去
+const DefaultJSONWindow = 1000
+
+// jsonFlushSize 输出缓冲超过这个大小就写出；有待翻译值时提前批量翻译这一段（窗口未满也写），内存不随输入增长
+const jsonFlushSize = 32 << 10
+
+// jsonCtxCheck 每读这么多 token 检查一次 ctx（长数组内也能及时取消）
+const jsonCtxCheck = 1024
+
+// TranslateJSON 流式翻译 JSON：从 src 按 token 读，原样写到 dst，命中 schema 规则的标量值后面插入同级译文 key
+// （"status":3 -> "status":3,"statusName":"已发货"）。不反序列化成结构体或 map，键顺序与数字原文保持不变；
+// 内存只和窗口大小有关（window <= 0 用 DefaultJSONWindow），每个窗口内 DB 类来源按分组批量预取一次。
+// 规则写法同 TranslateMap；src 里有多个顶层值（NDJSON）时逐个处理，每个顶层值后跟换行（同 json.Encoder）。
+// 对象里已有译文 key 时不重复写：已有的在前则保留原值、不插入；在后则译文已写出时跳过原值，否则保留原值
+func TranslateJSON(ctx context.Context, dst io.Writer, src io.Reader, schema *MapSchema, window int) error {
+	return defaultManager.TranslateJSON(ctx, dst, src, schema, window)
+}
+
+// TranslateJSON 流式翻译 JSON（实例方法）
+func (dm *DictManager) TranslateJSON(ctx context.Context, dst io.Writer, src io.Reader, schema *MapSchema, window int) error {
+	if schema == nil {
+		return ErrNilSchema // 一个字节都不读、不写
+	}
+	rules, err := dm.compileMapSchema(schema)
+	if err != nil {
+		return err
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if window <= 0 {
+		window = DefaultJSONWindow
+	}
+	s := &jsonStream{ctx: ctx, dst: dst, window: window, rules: make(map[string][]*mapRule),
+		leaves: make(map[string]bool), targets: make(map[string]bool)}
+	for i := range rules {
+		r := &rules[i]
+		k := strings.Join(r.path, "\x00")
+		s.rules[k] = append(s.rules[k], r)
+		s.leaves[r.path[len(r.path)-1]] = true
+		s.targets[r.target] = true
+	}
+	dec := json.NewDecoder(src)
+	dec.UseNumber()
+	if err := s.run(dec); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+// jsonFrame 一层对象 / 数组
+type jsonFrame struct {
+	object    bool
+	n         int    // 已写出的成员数
+	expectKey bool   // 对象里下一个 token 是 key
+	key       string // 对象里当前成员的 key
+	// 对象里出现过的译文 key（原有的或插入的），防止写出重复 key
+	inserts map[string]*jsonInsert
+}
+
+// jsonInsert 对象里一个译文 key 的状态
+type jsonInsert struct {
+	cancel  bool // 对象里已有这个 key：不插入译文
+	written bool // 译文已写出：之后出现的原有同名成员跳过
+}
+
+// jsonPending 一个待翻译的值；译文在窗口批量查完后渲染成 ,"target":"label"
+type jsonPending struct {
+	before []byte // 它之前的一段原样输出
+	rule   *mapRule
+	field  string
+	key    any
+	ins    *jsonInsert
+}
+
+type jsonStream struct {
+	ctx     context.Context
+	dst     io.Writer
+	window  int
+	rules   map[string][]*mapRule // 路径（\x00 连接）-> 规则
+	leaves  map[string]bool       // 规则路径的最后一段，先用它筛，命中了才拼完整路径
+	targets map[string]bool       // 规则的译文 key
+	stack   []jsonFrame
+	cur     bytes.Buffer
+	pending []jsonPending
+	held    int // pending 里 before 的总字节数
+	tokens  int
+}
+
+func (s *jsonStream) run(dec *json.Decoder) error {
+	top := 0
+	for {
+		if len(s.stack) == 0 && top > 0 {
+			s.cur.WriteByte('\n')
+		}
+		if s.held+s.cur.Len() >= jsonFlushSize {
+			if err := s.flush(); err != nil {
+				return err
+			}
+		}
+		tok, err := s.token(dec)
+		if errors.Is(err, io.EOF) {
+			if len(s.stack) > 0 {
+				return io.ErrUnexpectedEOF
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if len(s.stack) == 0 {
+			top++
+		}
+
+		// 对象里的 key
+		if n := len(s.stack); n > 0 && s.stack[n-1].object && s.stack[n-1].expectKey {
+			f := &s.stack[n-1]
+			if d, ok := tok.(json.Delim); ok && d == '}' {
+				s.cur.WriteByte('}')
+				s.pop()
+				continue
+			}
+			key, _ := tok.(string)
+			if s.targets[key] {
+				if in := f.inserts[key]; in != nil && in.written {
+					// 译文已写出：跳过原有的同名成员
+					if err := s.skipValue(dec); err != nil {
+						return err
+					}
+					continue
+				} else if in != nil {
+					in.cancel = true
+				} else {
+					if f.inserts == nil {
+						f.inserts = make(map[string]*jsonInsert)
+					}
+					f.inserts[key] = &jsonInsert{cancel: true}
+				}
+			}
+			if f.n > 0 {
+				s.cur.WriteByte(',')
+			}
+			f.n++
+			f.key = key
+			writeJSONString(&s.cur, f.key)
+			s.cur.WriteByte(':')
+			f.expectKey = false
+			continue
+		}
+
+		// 数组元素之间的逗号
+		if n := len(s.stack); n > 0 && !s.stack[n-1].object {
+			if d, ok := tok.(json.Delim); ok && d == ']' {
+				s.cur.WriteByte(']')
+				s.pop()
+				continue
+			}
+			if s.stack[n-1].n > 0 {
+				s.cur.WriteByte(',')
+			}
+			s.stack[n-1].n++
+		}
+
+		switch v := tok.(type) {
+		case json.Delim:
+			s.cur.WriteByte(byte(v))
+			s.stack = append(s.stack, jsonFrame{object: v == '{', expectKey: v == '{'})
+			continue
+		case string:
+			writeJSONString(&s.cur, v)
+		case json.Number:
+			s.cur.WriteString(v.String())
+		case bool:
+			if v {
+				s.cur.WriteString("true")
+			} else {
+				s.cur.WriteString("false")
+			}
+		case nil:
+			s.cur.WriteString("null")
+		}
+		if err := s.valueDone(tok); err != nil {
+			return err
+		}
+	}
+}
+
+// token 读下一个 token，每 jsonCtxCheck 个检查一次 ctx
+func (s *jsonStream) token(dec *json.Decoder) (json.Token, error) {
+	if s.tokens++; s.tokens%jsonCtxCheck == 1 {
+		if err := s.ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return dec.Token()
+}
+
+// skipValue 读掉一个值（可以是对象 / 数组）不输出；所在对象转回等 key
+func (s *jsonStream) skipValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		tok, err := s.token(dec)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return io.ErrUnexpectedEOF
+			}
+			return err
+		}
+		if d, ok := tok.(json.Delim); ok {
+			if d == '{' || d == '[' {
+				depth++
+			} else {
+				depth--
+			}
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// pop 结束一层对象 / 数组，父对象转回等 key
+func (s *jsonStream) pop() {
+	s.stack = s.stack[:len(s.stack)-1]
+	if n := len(s.stack); n > 0 && s.stack[n-1].object {
+		s.stack[n-1].expectKey = true
+	}
+}
+
+// valueDone 写完一个标量：所在对象成员命中规则时登记待翻译，窗口满了就批量翻译并写出
+func (s *jsonStream) valueDone(tok any) error {
+	n := len(s.stack)
+	if n == 0 || !s.stack[n-1].object {
+		return nil
+	}
+	f := &s.stack[n-1]
+	f.expectKey = true
+	if !s.leaves[f.key] {
+		return nil
+	}
+	key, ok := mapKey(tok)
+	if !ok {
+		return nil
+	}
+	path := make([]string, 0, n)
+	for i := range s.stack {
+		if s.stack[i].object {
+			path = append(path, s.stack[i].key)
+		}
+	}
+	for _, r := range s.rules[strings.Join(path, "\x00")] {
+		if f.inserts[r.target] != nil {
+			continue // 对象里已有（或已插入）这个译文 key
+		}
+		if f.inserts == nil {
+			f.inserts = make(map[string]*jsonInsert)
+		}
+		in := &jsonInsert{}
+		f.inserts[r.target] = in
+		before := append([]byte(nil), s.cur.Bytes()...)
+		s.cur.Reset()
+		s.held += len(before)
+		s.pending = append(s.pending, jsonPending{before: before, rule: r, field: f.key, key: key, ins: in})
+	}
+	if len(s.pending) >= s.window {
+		return s.flush()
+	}
+	return nil
+}
+
+// flush 批量预取本窗口的 key，按顺序写出各段输出与译文
+func (s *jsonStream) flush() error {
+	if len(s.pending) == 0 {
+		return s.writeCur()
+	}
+	if len(s.pending) >= GetConfig().Performance.BatchQueryThreshold {
+		w := newCollectWalk(s.ctx)
+		for i := range s.pending {
+			w.add(s.ctx, s.pending[i].rule.step.tr, s.pending[i].key)
+		}
+		if err := w.flush(); err != nil {
+			return err
+		}
+	}
+	var out bytes.Buffer
+	for i := range s.pending {
+		p := &s.pending[i]
+		out.Write(p.before)
+		if p.ins.cancel {
+			continue
+		}
+		var label string
+		var err error
+		if ct, ok := p.rule.step.tr.(ContextTranslator); ok {
+			label, err = ct.TranslateContext(s.ctx, p.key, p.field, p.rule.step.tag)
+		} else {
+			label, err = p.rule.step.tr.Translate(p.key, p.field, p.rule.step.tag)
+		}
+		if err != nil {
+			return fmt.Errorf("dict-trans: translate %q: %w", p.field, err)
+		}
+		if label == "" {
+			continue
+		}
+		if l, suffix, disabled := splitDisabled(label); disabled {
+			label = l + suffix
+		}
+		out.WriteByte(',')
+		writeJSONString(&out, p.rule.target)
+		out.WriteByte(':')
+		writeJSONString(&out, label)
+		p.ins.written = true
+	}
+	s.pending = s.pending[:0]
+	s.held = 0
+	if _, err := s.dst.Write(out.Bytes()); err != nil {
+		return err
+	}
+	return s.writeCur()
+}
+
+func (s *jsonStream) writeCur() error {
+	if s.cur.Len() == 0 {
+		return nil
+	}
+	_, err := s.dst.Write(s.cur.Bytes())
+	s.cur.Reset()
+	return err
+}
+
+// writeJSONString 写 JSON 字符串：只转义引号、反斜杠和控制字符（不转义 <、>、&，与原文更接近）
+func writeJSONString(buf *bytes.Buffer, v string) {
+	const hex = "0123456789abcdef"
+	buf.WriteByte('"')
+	start := 0
+	for i := 0; i < len(v); i++ {
+		c := v[i]
+		if c >= 0x20 && c != '"' && c != '\\' {
+			continue
+		}
+		buf.WriteString(v[start:i])
+		switch c {
+		case '"', '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			buf.WriteString(`\u00`)
+			buf.WriteByte(hex[c>>4])
+			buf.WriteByte(hex[c&0xf])
+		}
+		start = i + 1
+	}
+	buf.WriteString(v[start:])
+	buf.WriteByte('"')
+}
@@ -0,0 +1,137 @@
+package dict
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestTranslateJSONStream(t *testing.T) {
+	EnableDBCache(true)
+	ClearDBCache()
+	t.Cleanup(ClearDBCache)
+	be := &deptDB{}
+	RegisterDBTranslator(be)
+	RegisterDict("sex", map[string]string{"1": "男", "2": "女"})
+
+	var src strings.Builder
+	src.WriteString(`{"total":30,"note":"a<b & \"q\"","rows":[`)
+	for i := 0; i < 30; i++ {
+		if i > 0 {
+			src.WriteString(",")
+		}
+		fmt.Fprintf(&src, `{"sex":%d,"amount":1.50,"dept":"%s","tags":[1,{"x":null}]}`, i%2+1, []string{"d1", "d2", "d4"}[i%3])
+	}
+	src.WriteString(`]}`)
+
+	schema := &MapSchema{Rules: []MapRule{
+		{Path: "rows.sex", Source: "dict:sex"},
+		{Path: "rows.dept", Source: "db:table=dept,key=id,value=name", Target: "deptLabel"},
+	}}
+	var out bytes.Buffer
+	if err := TranslateJSON(context.Background(), &out, strings.NewReader(src.String()), schema, 20); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out.String(), `{"total":30,"note":"a<b & \"q\"","rows":[{"sex":1,"sexName":"男","amount":1.50,"dept":"d1","deptLabel":"总公司","tags":[1,{"x":null}]}`) {
+		t.Fatalf("输出不对（键顺序 / 数字原文 / 转义）: %.200s", out.String())
+	}
+	var doc struct {
+		Rows []map[string]any `json:"rows"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &doc); err != nil {
+		t.Fatalf("输出不是合法 JSON: %v", err)
+	}
+	if len(doc.Rows) != 30 || doc.Rows[29]["sexName"] != "女" || doc.Rows[29]["deptLabel"] != "后端组" {
+		t.Fatalf("末尾元素翻译不对: %v", doc.Rows[29])
+	}
+	// 60 个待翻译值、窗口 20：首个窗口一次批量，之后的窗口全部命中缓存，不单查
+	if b, s := atomic.LoadInt64(&be.batch), atomic.LoadInt64(&be.single); b != 1 || s != 0 {
+		t.Fatalf("期望 1 次批量 0 次单查，实际 batch=%d single=%d", b, s)
+	}
+}
+
+func TestTranslateJSONMultipleValues(t *testing.T) {
+	RegisterDict("sex", map[string]string{"1": "男"})
+	schema := &MapSchema{Rules: []MapRule{{Path: "sex", Source: "dict:sex"}}}
+	var out bytes.Buffer
+	if err := TranslateJSON(context.Background(), &out, strings.NewReader(`{"sex":"1"} {"sex":"9"} 3`), schema, 0); err != nil {
+		t.Fatal(err)
+	}
+	if want := "{\"sex\":\"1\",\"sexName\":\"男\"}\n{\"sex\":\"9\"}\n3\n"; out.String() != want {
+		t.Fatalf("多个顶层值输出不对: %q", out.String())
+	}
+	if err := TranslateJSON(context.Background(), &out, strings.NewReader(`{"sex":`), schema, 0); err == nil {
+		t.Fatalf("截断的 JSON 应报错")
+	}
+	out.Reset()
+	if err := TranslateJSON(context.Background(), &out, strings.NewReader(`{"sex":"1"}`), nil, 0); err != ErrNilSchema || out.Len() != 0 {
+		t.Fatalf("nil schema 应返回 ErrNilSchema 且不输出: %v %q", err, out.String())
+	}
+}
+
+// maxWriter 记录单次 Write 的最大长度
+type maxWriter struct {
+	bytes.Buffer
+	max int
+}
+
+func (w *maxWriter) Write(p []byte) (int, error) {
+	if len(p) > w.max {
+		w.max = len(p)
+	}
+	return w.Buffer.Write(p)
+}
+
+func TestTranslateJSONBoundedMemory(t *testing.T) {
+	RegisterDict("sex", map[string]string{"1": "男"})
+	schema := &MapSchema{Rules: []MapRule{{Path: "sex", Source: "dict:sex"}}}
+	var src strings.Builder
+	src.WriteString(`[{"sex":"1"}`)
+	for i := 0; i < 50000; i++ {
+		src.WriteString(`,{"other":"xxxxxxxxxxxxxxxx"}`)
+	}
+	src.WriteString(`]`)
+	var out maxWriter
+	if err := TranslateJSON(context.Background(), &out, strings.NewReader(src.String()), schema, 0); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out.String(), `[{"sex":"1","sexName":"男"},`) {
+		t.Fatalf("输出不对: %.100s", out.String())
+	}
+	if out.max > 2*jsonFlushSize {
+		t.Fatalf("有待翻译值时也应按缓冲大小写出，单次写出 %d 字节", out.max)
+	}
+
+	// 长数组内取消
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := TranslateJSON(ctx, &out, strings.NewReader(src.String()), schema, 0); err != context.Canceled {
+		t.Fatalf("ctx 已取消应返回错误: %v", err)
+	}
+}
+
+func TestTranslateJSONExistingTarget(t *testing.T) {
+	RegisterDict("sex", map[string]string{"1": "男"})
+	schema := &MapSchema{Rules: []MapRule{{Path: "sex", Source: "dict:sex"}}}
+	in := `{"sexName":"原值","sex":"1"} {"sex":"1","sexName":"原值","x":{"a":[1]}} {"sex":"9","sexName":"原值"}`
+	var out bytes.Buffer
+	if err := TranslateJSON(context.Background(), &out, strings.NewReader(in), schema, 0); err != nil {
+		t.Fatal(err)
+	}
+	want := "{\"sexName\":\"原值\",\"sex\":\"1\"}\n{\"sex\":\"1\",\"sexName\":\"原值\",\"x\":{\"a\":[1]}}\n{\"sex\":\"9\",\"sexName\":\"原值\"}\n"
+	if out.String() != want {
+		t.Fatalf("已有译文 key 时不应重复: %q", out.String())
+	}
+	// 窗口为 1：译文先写出，之后原有的同名成员跳过
+	out.Reset()
+	if err := TranslateJSON(context.Background(), &out, strings.NewReader(`{"sex":"1","sexName":{"a":1},"b":2}`), schema, 1); err != nil {
+		t.Fatal(err)
+	}
+	if want := "{\"sex\":\"1\",\"sexName\":\"男\",\"b\":2}\n"; out.String() != want {
+		t.Fatalf("译文已写出时应跳过原有成员: %q", out.String())
+	}
+}
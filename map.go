@@ -0,0 +1,188 @@
+package dict
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MapRule TranslateMap 的一条规则
+//   - Path：源值路径，"." 分隔（如 "data.items.status"）；途中遇到数组逐个元素展开
+//   - Source：翻译来源，写法同 chain 的步骤：dict:sex、enum:orderStatus、dictTable:sex、dictTableTwo:sex、
+//     db:table=user,key=id,value=name、range:ageGroup、translate:user
+//   - Target：翻译结果写到源值所在 map 的哪个 key，默认源 key + "Name"（status -> statusName）
+type MapRule struct {
+	Path   string `json:"path"`
+	Source string `json:"source"`
+	Target string `json:"target,omitempty"`
+}
+
+// MapSchema 一组 MapRule；可直接构造，或用 ParseMapSchema 从 JSON 读
+type MapSchema struct {
+	Rules []MapRule `json:"rules"`
+}
+
+// ParseMapSchema 从 JSON 读取 schema：{"rules":[{"path":"items.status","source":"dict:status"}]}，
+// 也接受直接写成规则数组
+func ParseMapSchema(data []byte) (*MapSchema, error) {
+	var s MapSchema
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "[") {
+		if err := json.Unmarshal(data, &s.Rules); err != nil {
+			return nil, fmt.Errorf("dict-trans: parse map schema: %w", err)
+		}
+	} else if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("dict-trans: parse map schema: %w", err)
+	}
+	return &s, nil
+}
+
+// mapRule 编译后的规则
+type mapRule struct {
+	path   []string
+	target string
+	step   chainStep
+}
+
+// compileMapSchema 按当前注册表解析每条规则的来源；来源写错时返回错误
+func (dm *DictManager) compileMapSchema(schema *MapSchema) ([]mapRule, error) {
+	if schema == nil {
+		return nil, ErrNilSchema
+	}
+	rules := make([]mapRule, 0, len(schema.Rules))
+	for _, r := range schema.Rules {
+		path := strings.Split(strings.TrimSpace(r.Path), ".")
+		if r.Path == "" || path[len(path)-1] == "" {
+			return nil, fmt.Errorf("dict-trans: map rule: empty path")
+		}
+		st, ok := dm.parseStep(r.Source)
+		if !ok {
+			return nil, fmt.Errorf("dict-trans: map rule %q: invalid source %q", r.Path, r.Source)
+		}
+		target := r.Target
+		if target == "" {
+			target = path[len(path)-1] + "Name"
+		}
+		rules = append(rules, mapRule{path: path, target: target, step: st})
+	}
+	return rules, nil
+}
+
+// TranslateMap 按 schema 翻译没有结构体的 JSON 类文档（map[string]any，嵌套 map / []any 均可），
+// 译文写到源值旁边的目标 key。命中的源值数 >= Config.Performance.BatchQueryThreshold 时，
+// DB 类来源先按分组批量预取（与结构体切片相同）
+func TranslateMap(ctx context.Context, doc map[string]any, schema *MapSchema) error {
+	return defaultManager.TranslateMap(ctx, doc, schema)
+}
+
+// TranslateMap 按 schema 翻译 map 文档（实例方法）
+func (dm *DictManager) TranslateMap(ctx context.Context, doc map[string]any, schema *MapSchema) error {
+	if doc == nil {
+		return ErrNilMap
+	}
+	rules, err := dm.compileMapSchema(schema)
+	if err != nil {
+		return err
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// 收集：按规则找出全部源值，够阈值就批量预取
+	w := newCollectWalk(ctx)
+	n := 0
+	for i := range rules {
+		r := &rules[i]
+		visitMapPath(doc, r.path, func(m map[string]any, key string) {
+			if k, ok := mapKey(m[key]); ok {
+				w.add(ctx, r.step.tr, k)
+				n++
+			}
+		})
+	}
+	if n > 0 && n >= GetConfig().Performance.BatchQueryThreshold {
+		if err := w.flush(); err != nil {
+			return err
+		}
+	}
+
+	// 翻译
+	for i := range rules {
+		r := &rules[i]
+		var firstErr error
+		visitMapPath(doc, r.path, func(m map[string]any, key string) {
+			if firstErr != nil {
+				return
+			}
+			k, ok := mapKey(m[key])
+			if !ok {
+				return
+			}
+			var label string
+			if ct, ok := r.step.tr.(ContextTranslator); ok {
+				label, firstErr = ct.TranslateContext(ctx, k, key, r.step.tag)
+			} else {
+				label, firstErr = r.step.tr.Translate(k, key, r.step.tag)
+			}
+			if label != "" {
+				if l, suffix, disabled := splitDisabled(label); disabled {
+					label = l + suffix
+				}
+				m[r.target] = label
+			}
+		})
+		if firstErr != nil {
+			return firstErr
+		}
+	}
+	return nil
+}
+
+// visitMapPath 沿路径找到每个 "源值所在 map + key"；中途遇到 []any / []map[string]any 逐个展开
+func visitMapPath(v any, path []string, fn func(m map[string]any, key string)) {
+	switch x := v.(type) {
+	case map[string]any:
+		if len(path) == 1 {
+			if _, ok := x[path[0]]; ok {
+				fn(x, path[0])
+			}
+			return
+		}
+		if next, ok := x[path[0]]; ok {
+			visitMapPath(next, path[1:], fn)
+		}
+	case []any:
+		for _, e := range x {
+			visitMapPath(e, path, fn)
+		}
+	case []map[string]any:
+		for _, e := range x {
+			visitMapPath(e, path, fn)
+		}
+	}
+}
+
+// mapKey JSON 值转成 key：整数值的 float64（encoding/json 的数字）按整数写，json.Number 取原文，
+// nil、map、数组不翻译
+func mapKey(v any) (any, bool) {
+	switch x := v.(type) {
+	case nil:
+		return nil, false
+	case string:
+		return x, x != ""
+	case float64:
+		if x == math.Trunc(x) && math.Abs(x) < 1<<53 {
+			return int64(x), true
+		}
+		return strconv.FormatFloat(x, 'f', -1, 64), true
+	case json.Number:
+		return x.String(), true
+	case bool:
+		return strconv.FormatBool(x), true
+	case map[string]any, []any:
+		return nil, false
+	}
+	return v, true
+}
@@ -0,0 +1,72 @@
+package dict
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+)
+
+func TestTranslateMap(t *testing.T) {
+	EnableDBCache(true)
+	ClearDBCache()
+	t.Cleanup(ClearDBCache)
+	be := &deptDB{}
+	RegisterDBTranslator(be)
+	RegisterDict("sex", map[string]string{"1": "男", "2": "女"})
+	RegisterEnum("orderStatus", map[string]string{"3": "已发货"})
+
+	var doc map[string]any
+	raw := `{"data":{"status":3,"items":[` +
+		`{"sex":"1","dept":"d1"},{"sex":"2","dept":"d2"},{"sex":"1","dept":"d4"},{"sex":"2","dept":"d1"},` +
+		`{"sex":"1","dept":"d2"},{"sex":"2","dept":"d4"},{"sex":"1","dept":"d1"},{"sex":"2","dept":"d2"},` +
+		`{"sex":"1","dept":"d4"},{"sex":"9","dept":"d1"},{"sex":null}]}}`
+	if err := json.Unmarshal([]byte(raw), &doc); err != nil {
+		t.Fatal(err)
+	}
+	schema, err := ParseMapSchema([]byte(`[
+		{"path":"data.status","source":"enum:orderStatus"},
+		{"path":"data.items.sex","source":"dict:sex","target":"sexLabel"},
+		{"path":"data.items.dept","source":"db:table=dept,key=id,value=name"}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := TranslateMap(context.Background(), doc, schema); err != nil {
+		t.Fatal(err)
+	}
+	data := doc["data"].(map[string]any)
+	items := data["items"].([]any)
+	first := items[0].(map[string]any)
+	if data["statusName"] != "已发货" || first["sexLabel"] != "男" || first["deptName"] != "总公司" {
+		t.Fatalf("map 翻译结果不对: %v", data)
+	}
+	if _, ok := items[9].(map[string]any)["sexLabel"]; ok {
+		t.Fatalf("查不到的值不应写目标 key")
+	}
+	if _, ok := items[10].(map[string]any)["sexLabel"]; ok {
+		t.Fatalf("null 值不应翻译")
+	}
+	if b, s := atomic.LoadInt64(&be.batch), atomic.LoadInt64(&be.single); b != 1 || s != 0 {
+		t.Fatalf("期望 1 次批量 0 次单查，实际 batch=%d single=%d", b, s)
+	}
+}
+
+func TestTranslateMapSchemaErrors(t *testing.T) {
+	doc := map[string]any{"a": "1"}
+	if err := TranslateMap(context.Background(), doc, &MapSchema{Rules: []MapRule{{Path: "a", Source: "nope"}}}); err == nil {
+		t.Fatalf("来源写错应报错")
+	}
+	if err := TranslateMap(context.Background(), doc, &MapSchema{Rules: []MapRule{{Path: "", Source: "dict:sex"}}}); err == nil {
+		t.Fatalf("空路径应报错")
+	}
+	if err := TranslateMap(context.Background(), nil, &MapSchema{}); err != ErrNilMap {
+		t.Fatalf("nil 文档应返回 ErrNilMap: %v", err)
+	}
+	if err := TranslateMap(context.Background(), doc, nil); err != ErrNilSchema {
+		t.Fatalf("nil schema 应返回 ErrNilSchema: %v", err)
+	}
+	if _, err := ParseMapSchema([]byte(`{"rules":[{"path":"a","source":"dict:sex"}]}`)); err != nil {
+		t.Fatal(err)
+	}
+}
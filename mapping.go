@@ -0,0 +1,210 @@
+package dict
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// FieldMapping 用代码声明一个字段的翻译（给不能加标签的第三方 / 生成代码结构体用），等价于在字段上写
+// `<Source>:"<Value>" dictField:"<Target>"`。同一字段的映射覆盖其上的翻译标签，其余字段的标签照常生效
+//   - Field：源字段名
+//   - Source：来源，同标签名：dict、enum、range、translate、db、chain、tree、switch、dictTable、dictTableTwo
+//   - Value：标签值（如 "status"、"table=user,key=id,value=name"、"perm,flags"）
+//   - Target：目标字段名（多目标用逗号分隔），可空（只供 Marshal / Labels 用）
+type FieldMapping struct {
+	Field  string
+	Source string
+	Value  string
+	Target string
+}
+
+var mappingSources = map[string]bool{
+	"dict": true, "enum": true, "range": true, "translate": true, "db": true,
+	"chain": true, "tree": true, "switch": true, "dictTable": true, "dictTableTwo": true,
+}
+
+// tag 映射对应的结构体标签
+func (m FieldMapping) tag() reflect.StructTag {
+	tag := m.Source + ":" + strconv.Quote(m.Value)
+	if m.Target != "" {
+		tag += " dictField:" + strconv.Quote(m.Target)
+	}
+	return reflect.StructTag(tag)
+}
+
+// RegisterTypeMapping 为类型 t（结构体或其指针）登记字段映射；与之前登记的同类型映射合并，同字段后者覆盖。
+// 字段名、目标字段或来源写错时返回错误。登记后清空配置缓存，已翻译过的类型也按新映射
+func RegisterTypeMapping(t reflect.Type, mappings []FieldMapping) error {
+	return defaultManager.RegisterTypeMapping(t, mappings)
+}
+
+// RegisterMapping 同 RegisterTypeMapping，类型由类型参数给出：dict.RegisterMapping[pb.Order](...)
+func RegisterMapping[T any](mappings ...FieldMapping) error {
+	return defaultManager.RegisterTypeMapping(reflect.TypeOf((*T)(nil)).Elem(), mappings)
+}
+
+// RegisterTypeMapping 登记字段映射（实例方法）
+func (dm *DictManager) RegisterTypeMapping(t reflect.Type, mappings []FieldMapping) error {
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return fmt.Errorf("dict-trans: type mapping: %v is not a struct", t)
+	}
+	for _, m := range mappings {
+		if err := checkMapping(t, m); err != nil {
+			return err
+		}
+	}
+	dm.updateReg(func(r *registry) {
+		next := make(map[string]FieldMapping, len(r.mappings[t])+len(mappings))
+		for k, v := range r.mappings[t] {
+			next[k] = v
+		}
+		for _, m := range mappings {
+			next[m.Field] = m
+		}
+		r.mappings[t] = next
+	})
+
+	dm.resetConfigs()
+	return nil
+}
+
+func checkMapping(t reflect.Type, m FieldMapping) error {
+	sf, ok := t.FieldByName(m.Field)
+	if !ok || len(sf.Index) != 1 || !sf.IsExported() {
+		return fmt.Errorf("dict-trans: type mapping: %s has no exported field %q", t, m.Field)
+	}
+	if !mappingSources[m.Source] {
+		return fmt.Errorf("dict-trans: type mapping %s.%s: unknown source %q", t, m.Field, m.Source)
+	}
+	if m.Value == "" {
+		return fmt.Errorf("dict-trans: type mapping %s.%s: empty %s value", t, m.Field, m.Source)
+	}
+	if m.Source == "db" {
+		if spec, ok := parseDBSpec(m.Value); ok && !spec.targetsMatch(m.Target) {
+			return fmt.Errorf("dict-trans: type mapping %s.%s: %s needs one target per value column", t, m.Field, m.Value)
+		}
+	}
+	if m.Target != "" {
+		for _, name := range strings.Split(m.Target, ",") {
+			if fieldIndexByName(t, strings.TrimSpace(name)) < 0 {
+				return fmt.Errorf("dict-trans: type mapping %s.%s: no target field %q", t, m.Field, name)
+			}
+		}
+	}
+	return nil
+}
+
+// TypeMapping For[T]() 返回的构建器：.Field("Status").Dict("status").Into("StatusName") 每写完一条即登记。
+// 字段可用名字，也可用取址函数（FieldFunc / IntoFunc）让编译器检查字段；写错时 panic（启动期即暴露）
+type TypeMapping[T any] struct {
+	dm *DictManager
+	t  reflect.Type
+}
+
+// For 类型 T 的映射构建器（登记到全局实例）
+func For[T any]() *TypeMapping[T] {
+	return ForManager[T](defaultManager)
+}
+
+// ForManager 类型 T 的映射构建器（登记到 dm）
+func ForManager[T any](dm *DictManager) *TypeMapping[T] {
+	return &TypeMapping[T]{dm: dm, t: reflect.TypeOf((*T)(nil)).Elem()}
+}
+
+// Field 按名字选源字段
+func (b *TypeMapping[T]) Field(name string) *FieldMappingBuilder[T] {
+	return &FieldMappingBuilder[T]{b: b, m: FieldMapping{Field: name}}
+}
+
+// FieldFunc 按取址函数选源字段：FieldFunc(func(o *Order) any { return &o.Status })
+func (b *TypeMapping[T]) FieldFunc(fn func(*T) any) *FieldMappingBuilder[T] {
+	return b.Field(fieldNameOf(b.t, fn))
+}
+
+// FieldMappingBuilder 一条映射的构建器：选来源后 Into 登记
+type FieldMappingBuilder[T any] struct {
+	b *TypeMapping[T]
+	m FieldMapping
+}
+
+func (f *FieldMappingBuilder[T]) source(kind, value string) *FieldMappingBuilder[T] {
+	f.m.Source, f.m.Value = kind, value
+	return f
+}
+
+// Dict 内存字典（RegisterDict）
+func (f *FieldMappingBuilder[T]) Dict(name string) *FieldMappingBuilder[T] {
+	return f.source("dict", name)
+}
+
+// Enum 枚举（RegisterEnum；"perm,flags" 为位掩码枚举）
+func (f *FieldMappingBuilder[T]) Enum(name string) *FieldMappingBuilder[T] {
+	return f.source("enum", name)
+}
+
+// Range 区间字典（RegisterRangeDict）
+func (f *FieldMappingBuilder[T]) Range(name string) *FieldMappingBuilder[T] {
+	return f.source("range", name)
+}
+
+// DictTable 单表字典
+func (f *FieldMappingBuilder[T]) DictTable(dictType string) *FieldMappingBuilder[T] {
+	return f.source("dictTable", dictType)
+}
+
+// DictTableTwo 双表字典
+func (f *FieldMappingBuilder[T]) DictTableTwo(dictTypeCode string) *FieldMappingBuilder[T] {
+	return f.source("dictTableTwo", dictTypeCode)
+}
+
+// DB 查表："table=user,key=id,value=name"
+func (f *FieldMappingBuilder[T]) DB(spec string) *FieldMappingBuilder[T] { return f.source("db", spec) }
+
+// Translate 自定义翻译器（RegisterTranslator）
+func (f *FieldMappingBuilder[T]) Translate(name string) *FieldMappingBuilder[T] {
+	return f.source("translate", name)
+}
+
+// Source 其余来源（chain、tree、switch 等），写法同对应标签
+func (f *FieldMappingBuilder[T]) Source(kind, value string) *FieldMappingBuilder[T] {
+	return f.source(kind, value)
+}
+
+// Into 指定目标字段并登记，返回类型构建器以便继续 .Field(...)
+func (f *FieldMappingBuilder[T]) Into(target string) *TypeMapping[T] {
+	f.m.Target = target
+	if err := f.b.dm.RegisterTypeMapping(f.b.t, []FieldMapping{f.m}); err != nil {
+		panic(err)
+	}
+	return f.b
+}
+
+// IntoFunc 按取址函数指定目标字段并登记
+func (f *FieldMappingBuilder[T]) IntoFunc(fn func(*T) any) *TypeMapping[T] {
+	return f.Into(fieldNameOf(f.b.t, fn))
+}
+
+// fieldNameOf 取址函数返回的字段指针 -> 字段名（按相对结构体起始地址的偏移与类型匹配顶层字段）
+func fieldNameOf(t reflect.Type, fn any) string {
+	p := reflect.New(t)
+	out := reflect.ValueOf(fn).Call([]reflect.Value{p})[0]
+	if out.Kind() == reflect.Interface {
+		out = out.Elem()
+	}
+	if out.Kind() != reflect.Ptr || out.IsNil() {
+		panic(fmt.Sprintf("dict-trans: type mapping %s: accessor must return a field pointer (&o.Field)", t))
+	}
+	off := out.Pointer() - p.Pointer()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.Offset == off && sf.Type == out.Type().Elem() {
+			return sf.Name
+		}
+	}
+	panic(fmt.Sprintf("dict-trans: type mapping %s: accessor does not point to a top-level field", t))
+}
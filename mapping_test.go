@@ -0,0 +1,78 @@
+package dict
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// 模拟生成代码：不能加标签
+type mappingOrder struct {
+	Status     int32
+	StatusName string
+	Sex        string `dict:"sex" dictField:"SexName"`
+	SexName    string
+	Kind       string
+	KindName   *string
+}
+
+func TestRegisterTypeMapping(t *testing.T) {
+	RegisterDict("sex", map[string]string{"1": "男", "2": "女"})
+	RegisterEnum("mappingStatus", map[string]string{"3": "已发货"})
+	err := RegisterTypeMapping(reflect.TypeOf(&mappingOrder{}), []FieldMapping{
+		{Field: "Status", Source: "enum", Value: "mappingStatus", Target: "StatusName"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := &mappingOrder{Status: 3, Sex: "2"}
+	if err := Translate(o); err != nil || o.StatusName != "已发货" || o.SexName != "女" {
+		t.Fatalf("映射应与标签合并: %v %+v", err, o)
+	}
+
+	// 覆盖字段上的标签
+	if err := RegisterMapping[mappingOrder](FieldMapping{Field: "Sex", Source: "enum", Value: "mappingStatus", Target: "SexName"}); err != nil {
+		t.Fatal(err)
+	}
+	o = &mappingOrder{Status: 3, Sex: "3"}
+	if err := Translate(o); err != nil || o.SexName != "已发货" || o.StatusName != "已发货" {
+		t.Fatalf("映射应覆盖标签且保留之前的映射: %v %+v", err, o)
+	}
+
+	bad := []FieldMapping{
+		{Field: "Nope", Source: "dict", Value: "sex"},
+		{Field: "Kind", Source: "bogus", Value: "sex"},
+		{Field: "Kind", Source: "dict", Value: ""},
+		{Field: "Kind", Source: "dict", Value: "sex", Target: "Missing"},
+	}
+	for _, m := range bad {
+		if err := RegisterTypeMapping(reflect.TypeOf(mappingOrder{}), []FieldMapping{m}); err == nil {
+			t.Fatalf("应返回错误: %+v", m)
+		}
+	}
+	if err := RegisterTypeMapping(reflect.TypeOf(1), nil); err == nil {
+		t.Fatalf("非结构体应返回错误")
+	}
+}
+
+func TestForBuilder(t *testing.T) {
+	RegisterDict("sex", map[string]string{"1": "男", "2": "女"})
+	dm := NewDictManager()
+	dm.RegisterDict("kind", map[string]string{"a": "甲"})
+	ForManager[mappingOrder](dm).
+		FieldFunc(func(o *mappingOrder) any { return &o.Kind }).Dict("kind").IntoFunc(func(o *mappingOrder) any { return &o.KindName }).
+		Field("Status").Source("dict", "kind").Into("StatusName")
+
+	o := &mappingOrder{Kind: "a"}
+	if err := dm.Translate(o); err != nil || o.KindName == nil || *o.KindName != "甲" {
+		t.Fatalf("构建器映射未生效: %v %+v", err, o)
+	}
+
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), "top-level field") {
+			t.Fatalf("取址函数不指向字段时应 panic: %v", r)
+		}
+	}()
+	var x string
+	ForManager[mappingOrder](dm).FieldFunc(func(o *mappingOrder) any { return &x })
+}
@@ -0,0 +1,564 @@
+package dict
+
+import (
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// MarshalOption Marshal / Labeled 的选项
+type MarshalOption func(*marshalOpts)
+
+type marshalOpts struct {
+	labelKey func(jsonName string) string
+}
+
+// WithLabelSuffix 译文 key = 源字段 JSON 名 + suffix（默认 "Label"：status -> statusLabel）
+func WithLabelSuffix(suffix string) MarshalOption {
+	return func(o *marshalOpts) { o.labelKey = func(name string) string { return name + suffix } }
+}
+
+// WithLabelKey 自定义译文 key 的命名（如 func(n string) string { return n + "_text" }）
+func WithLabelKey(fn func(jsonName string) string) MarshalOption {
+	return func(o *marshalOpts) { o.labelKey = fn }
+}
+
+// Marshal 序列化成 JSON，并在每个带翻译标签、没有 dictField 的字段后面补一个同级译文 key
+// （"status":1,"statusLabel":"已支付"）。不改动入参：深拷贝后翻译副本（有 dictField 的字段照常写进目标字段）；
+// 标签、嵌套结构体 / 切片 / map、visited 语义都与 Translate 相同。字段名、omitempty / omitzero、字符串转义、内嵌展开按 encoding/json 的规则，
+// 实现了 json.Marshaler / encoding.TextMarshaler 的类型原样交给 encoding/json
+func Marshal(ctx context.Context, v any, opts ...MarshalOption) ([]byte, error) {
+	return defaultManager.Marshal(ctx, v, opts...)
+}
+
+// Marshal 序列化并补译文 key（实例方法）
+func (dm *DictManager) Marshal(ctx context.Context, v any, opts ...MarshalOption) ([]byte, error) {
+	o := marshalOpts{labelKey: func(name string) string { return name + "Label" }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return []byte("null"), nil
+	}
+	// 非指针先装进指针（Labels 只收指针）
+	if rv.Kind() != reflect.Ptr {
+		p := reflect.New(rv.Type())
+		p.Elem().Set(rv)
+		rv = p
+	} else if rv.IsNil() {
+		return []byte("null"), nil
+	}
+	cp, labels, err := dm.labelsOf(ctx, rv)
+	if err != nil {
+		return nil, err
+	}
+	e := &labelEncoder{dm: dm, labels: labels, labelKey: o.labelKey, ptrs: make(map[visitKey]string), active: make(map[visitKey]bool)}
+	if err := e.encode(cp, ""); err != nil {
+		return nil, err
+	}
+	return e.buf.Bytes(), nil
+}
+
+// Labeled 包一层 json.Marshaler：放进响应结构体或直接交给 json.Marshal / json.Encoder 时输出带译文 key 的 JSON
+func Labeled(ctx context.Context, v any, opts ...MarshalOption) json.Marshaler {
+	return labeled{dm: defaultManager, ctx: ctx, v: v, opts: opts}
+}
+
+// Labeled 包一层 json.Marshaler（实例方法）
+func (dm *DictManager) Labeled(ctx context.Context, v any, opts ...MarshalOption) json.Marshaler {
+	return labeled{dm: dm, ctx: ctx, v: v, opts: opts}
+}
+
+type labeled struct {
+	dm   *DictManager
+	ctx  context.Context
+	v    any
+	opts []MarshalOption
+}
+
+func (l labeled) MarshalJSON() ([]byte, error) { return l.dm.Marshal(l.ctx, l.v, l.opts...) }
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// labelEncoder 按 encoding/json 的规则写 JSON，结构体字段后面按 Labels 的路径补译文 key。
+// 同一指针目标 / map 被多处引用时，Labels 只记第一次到达的路径：这里记下首次路径，再遇到时按它查
+type labelEncoder struct {
+	dm       *DictManager
+	buf      bytes.Buffer
+	labels   map[string]string
+	labelKey func(string) string
+	ptrs     map[visitKey]string // 指针目标 / map -> 首次到达的路径
+	active   map[visitKey]bool   // 当前正在写的指针目标，再遇到即成环
+}
+
+func (e *labelEncoder) encode(v reflect.Value, path string) error {
+	if !v.IsValid() {
+		e.buf.WriteString("null")
+		return nil
+	}
+	t := v.Type()
+	if t.Kind() != reflect.Ptr && t.Kind() != reflect.Interface &&
+		(t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) ||
+			(v.CanAddr() && (reflect.PointerTo(t).Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType)))) {
+		return e.std(v)
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			e.buf.WriteString("null")
+			return nil
+		}
+		if t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) {
+			return e.std(v)
+		}
+		key := visitKey{addr: v.Pointer(), typ: t}
+		if e.active[key] {
+			return fmt.Errorf("dict-trans: marshal: encountered a cycle via %s", t)
+		}
+		if p, ok := e.ptrs[key]; ok {
+			path = p
+		} else {
+			e.ptrs[key] = path
+		}
+		e.active[key] = true
+		err := e.encode(v.Elem(), path)
+		delete(e.active, key)
+		return err
+	case reflect.Interface:
+		if v.IsNil() {
+			e.buf.WriteString("null")
+			return nil
+		}
+		return e.encode(v.Elem(), path)
+	case reflect.Struct:
+		return e.encodeStruct(v, path)
+	case reflect.Map:
+		if v.IsNil() {
+			e.buf.WriteString("null")
+			return nil
+		}
+		return e.encodeMap(v, path)
+	case reflect.Slice:
+		if v.IsNil() {
+			e.buf.WriteString("null")
+			return nil
+		}
+		if t.Elem().Kind() == reflect.Uint8 {
+			return e.std(v) // []byte 按 base64
+		}
+		fallthrough
+	case reflect.Array:
+		e.buf.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				e.buf.WriteByte(',')
+			}
+			if err := e.encode(v.Index(i), path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return err
+			}
+		}
+		e.buf.WriteByte(']')
+		return nil
+	}
+	return e.std(v)
+}
+
+// std 交给 encoding/json（标量、自定义 Marshaler）
+func (e *labelEncoder) std(v reflect.Value) error {
+	var x any
+	if v.Kind() != reflect.Ptr && v.CanAddr() {
+		x = v.Addr().Interface() // 指针接收者的 MarshalJSON 也能生效
+	} else {
+		x = v.Interface()
+	}
+	b, err := json.Marshal(x)
+	if err != nil {
+		return err
+	}
+	e.buf.Write(b)
+	return nil
+}
+
+// str 按 encoding/json 的规则写字符串（HTML 字符转义、非法 UTF-8 替换为 U+FFFD）
+func (e *labelEncoder) str(s string) {
+	b, _ := json.Marshal(s)
+	e.buf.Write(b)
+}
+
+func (e *labelEncoder) encodeStruct(v reflect.Value, path string) error {
+	fields := jsonFieldsOf(v.Type())
+	e.buf.WriteByte('{')
+	n := 0
+	for i := range fields {
+		f := &fields[i]
+		fv, ok := fieldByIndex(v, f.index)
+		if !ok || (f.omitEmpty && isEmptyJSON(fv)) || (f.omitZero && isZeroJSON(fv)) {
+			continue
+		}
+		if n > 0 {
+			e.buf.WriteByte(',')
+		}
+		n++
+		e.str(f.name)
+		e.buf.WriteByte(':')
+		fpath := path + f.path
+		if f.quoted {
+			if err := e.encodeQuoted(fv); err != nil {
+				return err
+			}
+		} else if err := e.encode(fv, fpath); err != nil {
+			return err
+		}
+
+		// 带翻译标签、没有目标字段的字段：补译文 key（与已有字段重名时不补）
+		if !e.labeled(f) {
+			continue
+		}
+		label, ok := e.labels[strings.TrimPrefix(fpath, ".")]
+		if !ok {
+			continue
+		}
+		key := e.labelKey(f.name)
+		if key == "" || hasJSONField(fields, key) {
+			continue
+		}
+		e.buf.WriteByte(',')
+		e.str(key)
+		e.buf.WriteByte(':')
+		e.str(label)
+	}
+	e.buf.WriteByte('}')
+	return nil
+}
+
+// labeled 字段带翻译标签且没有目标字段（dictField 未写或找不到）时要补译文 key
+func (e *labelEncoder) labeled(f *jsonField) bool {
+	if f.owner == nil {
+		return false
+	}
+	fc := e.dm.getOrCreateConfig(f.owner).byIndex[f.index[len(f.index)-1]]
+	return fc != nil && fc.targetFieldIndex < 0 && fc.targetIndexes == nil
+}
+
+// encodeQuoted 处理 `json:",string"`：数字、布尔写成字符串，字符串再转义一次
+func (e *labelEncoder) encodeQuoted(v reflect.Value) error {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			e.buf.WriteString("null")
+			return nil
+		}
+		v = v.Elem()
+	}
+	b, err := json.Marshal(v.Interface())
+	if err != nil {
+		return err
+	}
+	switch v.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		e.buf.WriteByte('"')
+		e.buf.Write(b)
+		e.buf.WriteByte('"')
+		return nil
+	case reflect.String:
+		b, err = json.Marshal(string(b))
+		if err != nil {
+			return err
+		}
+	}
+	e.buf.Write(b)
+	return nil
+}
+
+func (e *labelEncoder) encodeMap(v reflect.Value, path string) error {
+	key := visitKey{addr: v.Pointer(), typ: v.Type()}
+	if e.active[key] {
+		return fmt.Errorf("dict-trans: marshal: encountered a cycle via %s", v.Type())
+	}
+	if p, ok := e.ptrs[key]; ok {
+		path = p
+	} else {
+		e.ptrs[key] = path
+	}
+	e.active[key] = true
+	defer delete(e.active, key)
+
+	type kv struct {
+		name string
+		key  reflect.Value
+	}
+	entries := make([]kv, 0, v.Len())
+	for _, k := range v.MapKeys() {
+		name, err := jsonMapKey(k)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, kv{name: name, key: k})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+	e.buf.WriteByte('{')
+	for i, en := range entries {
+		if i > 0 {
+			e.buf.WriteByte(',')
+		}
+		e.str(en.name)
+		e.buf.WriteByte(':')
+		if err := e.encode(v.MapIndex(en.key), fmt.Sprintf("%s[%v]", path, en.key.Interface())); err != nil {
+			return err
+		}
+	}
+	e.buf.WriteByte('}')
+	return nil
+}
+
+// jsonMapKey map key 转 JSON 对象 key（同 encoding/json：字符串、TextMarshaler、整数）
+func jsonMapKey(k reflect.Value) (string, error) {
+	if k.Kind() == reflect.String {
+		if !k.Type().Implements(textMarshalerType) {
+			return k.String(), nil
+		}
+		// 字符串类型又实现了 TextMarshaler：谁优先因 encoding/json 版本而异，交给它决定
+		m := reflect.MakeMapWithSize(reflect.MapOf(k.Type(), reflect.TypeOf(0)), 1)
+		m.SetMapIndex(k, reflect.ValueOf(0))
+		b, err := json.Marshal(m.Interface())
+		if err != nil {
+			return "", err
+		}
+		var obj map[string]int
+		if err := json.Unmarshal(b, &obj); err != nil {
+			return "", err
+		}
+		for name := range obj {
+			return name, nil
+		}
+	}
+	if tm, ok := k.Interface().(encoding.TextMarshaler); ok {
+		if k.Kind() == reflect.Ptr && k.IsNil() {
+			return "", nil
+		}
+		b, err := tm.MarshalText()
+		return string(b), err
+	}
+	switch k.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(k.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("dict-trans: marshal: unsupported map key type %s", k.Type())
+}
+
+// jsonField 结构体展开后的一个 JSON 成员
+type jsonField struct {
+	name      string
+	index     []int
+	path      string // Labels 路径里的字段段（内嵌字段逐层带上：".Base.Status"）
+	omitEmpty bool
+	omitZero  bool
+	quoted    bool
+	owner     reflect.Type // 字段直接所属的结构体类型（查翻译配置用）
+	tagged    bool         // json 标签里写了名字（同名冲突时优先）
+}
+
+var jsonFieldCache sync.Map // reflect.Type -> []jsonField
+
+// jsonFieldsOf 按 encoding/json 的规则列出结构体的 JSON 成员：导出字段、json 标签改名 / "-" 跳过，
+// 没写名字的内嵌结构体展开；同名时层级浅的胜出，同层里只有一个写了标签名的胜出，否则都丢掉
+func jsonFieldsOf(t reflect.Type) []jsonField {
+	if f, ok := jsonFieldCache.Load(t); ok {
+		return f.([]jsonField)
+	}
+	var all []jsonField
+	var walkType func(t reflect.Type, index []int, path string, visited map[reflect.Type]bool)
+	walkType = func(t reflect.Type, index []int, path string, visited map[reflect.Type]bool) {
+		if visited[t] {
+			return
+		}
+		visited[t] = true
+		defer delete(visited, t)
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			ft := sf.Type
+			if sf.Anonymous {
+				if ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+				if !sf.IsExported() && ft.Kind() != reflect.Struct {
+					continue
+				}
+			} else if !sf.IsExported() {
+				continue
+			}
+			tag := sf.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name, opts, _ := strings.Cut(tag, ",")
+			idx := append(append([]int(nil), index...), i)
+			fpath := path + "." + sf.Name
+			if name == "" && sf.Anonymous && ft.Kind() == reflect.Struct {
+				walkType(ft, idx, fpath, visited)
+				continue
+			}
+			f := jsonField{name: name, index: idx, path: fpath, tagged: name != ""}
+			if name == "" {
+				f.name = sf.Name
+			}
+			for _, o := range strings.Split(opts, ",") {
+				switch o {
+				case "omitempty":
+					f.omitEmpty = true
+				case "omitzero":
+					f.omitZero = jsonOmitZero
+				case "string":
+					qt := sf.Type
+					if qt.Name() == "" && qt.Kind() == reflect.Ptr {
+						qt = qt.Elem() // 同 encoding/json：*int 等也按字符串写
+					}
+					switch qt.Kind() {
+					case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+						reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+						reflect.Float32, reflect.Float64, reflect.String:
+						f.quoted = true
+					}
+				}
+			}
+			if sf.IsExported() {
+				f.owner = t
+			}
+			all = append(all, f)
+		}
+	}
+	walkType(t, nil, "", make(map[reflect.Type]bool))
+
+	// 同名冲突：层级浅（index 短）的胜出，同层只有一个写了标签名的胜出
+	byName := make(map[string][]int)
+	for i := range all {
+		byName[all[i].name] = append(byName[all[i].name], i)
+	}
+	fields := make([]jsonField, 0, len(all))
+	for i := range all {
+		group := byName[all[i].name]
+		if dominant(all, group) == i {
+			fields = append(fields, all[i])
+		}
+	}
+	actual, _ := jsonFieldCache.LoadOrStore(t, fields)
+	return actual.([]jsonField)
+}
+
+// dominant 同名成员里胜出的下标；没有唯一胜者时返回 -1
+func dominant(all []jsonField, group []int) int {
+	if len(group) == 1 {
+		return group[0]
+	}
+	minDepth := len(all[group[0]].index)
+	for _, i := range group {
+		if len(all[i].index) < minDepth {
+			minDepth = len(all[i].index)
+		}
+	}
+	win, tagged, n := -1, 0, 0
+	for _, i := range group {
+		if len(all[i].index) != minDepth {
+			continue
+		}
+		n++
+		if all[i].tagged {
+			tagged++
+			win = i
+		}
+	}
+	if n == 1 {
+		for _, i := range group {
+			if len(all[i].index) == minDepth {
+				return i
+			}
+		}
+	}
+	if tagged == 1 {
+		return win
+	}
+	return -1
+}
+
+func hasJSONField(fields []jsonField, name string) bool {
+	for i := range fields {
+		if fields[i].name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldByIndex 按下标链取字段；途中内嵌指针为 nil 时返回 false（encoding/json 跳过这些成员）
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// isEmptyJSON omitempty 的判空规则（同 encoding/json）
+func isEmptyJSON(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Interface, reflect.Ptr:
+		return v.IsZero()
+	}
+	return false
+}
+
+// jsonOmitZero 当前工具链的 encoding/json 是否支持 omitzero（Go 1.24 起），保持与 json.Marshal 一致
+var jsonOmitZero = func() bool {
+	b, _ := json.Marshal(struct {
+		A int `json:",omitzero"`
+	}{})
+	return string(b) == "{}"
+}()
+
+type jsonIsZeroer interface{ IsZero() bool }
+
+var jsonIsZeroerType = reflect.TypeOf((*jsonIsZeroer)(nil)).Elem()
+
+// isZeroJSON omitzero 的判零规则（同 encoding/json）：有 IsZero() bool 方法时以它为准，否则看零值
+func isZeroJSON(v reflect.Value) bool {
+	t := v.Type()
+	switch {
+	case t.Kind() == reflect.Ptr && t.Implements(jsonIsZeroerType):
+		return v.IsNil() || v.Interface().(jsonIsZeroer).IsZero()
+	case t.Kind() == reflect.Interface && t.Implements(jsonIsZeroerType):
+		return v.IsNil() || v.Interface().(jsonIsZeroer).IsZero()
+	case t.Implements(jsonIsZeroerType):
+		return v.Interface().(jsonIsZeroer).IsZero()
+	case reflect.PointerTo(t).Implements(jsonIsZeroerType):
+		if !v.CanAddr() {
+			c := reflect.New(t).Elem()
+			c.Set(v)
+			v = c
+		}
+		return v.Addr().Interface().(jsonIsZeroer).IsZero()
+	}
+	return v.IsZero()
+}
@@ -0,0 +1,181 @@
+package dict
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+type MarshalBase struct {
+	Status int `json:"status" enum:"marshalStatus"`
+}
+
+type marshalItem struct {
+	Sex        string       `json:"sex" dict:"sex"`
+	Gender     string       `json:"gender,omitempty" dict:"sex" dictField:"GenderName"`
+	GenderName string       `json:"genderName,omitempty"`
+	Next       *marshalItem `json:"next,omitempty"`
+}
+
+type marshalOrder struct {
+	MarshalBase
+	ID     int            `json:"id"`
+	Items  []marshalItem  `json:"items"`
+	Shared *marshalItem   `json:"shared"`
+	Again  *marshalItem   `json:"again"`
+	ByKey  map[string]any `json:"byKey"`
+	At     time.Time      `json:"at"`
+	Secret string         `json:"-" dict:"sex"`
+}
+
+func TestMarshal(t *testing.T) {
+	RegisterDict("sex", map[string]string{"1": "男", "2": "女"})
+	RegisterEnum("marshalStatus", map[string]string{"1": "已支付"})
+	shared := &marshalItem{Sex: "2"}
+	o := &marshalOrder{
+		MarshalBase: MarshalBase{Status: 1},
+		ID:          7,
+		Items:       []marshalItem{{Sex: "1", Gender: "2"}, {Sex: "9"}},
+		Shared:      shared,
+		Again:       shared,
+		ByKey:       map[string]any{"b": marshalItem{Sex: "1"}, "a": 3},
+		At:          time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		Secret:      "1",
+	}
+	b, err := Marshal(context.Background(), o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"status":1,"statusLabel":"已支付","id":7,` +
+		`"items":[{"sex":"1","sexLabel":"男","gender":"2","genderName":"女"},{"sex":"9"}],` +
+		`"shared":{"sex":"2","sexLabel":"女"},"again":{"sex":"2","sexLabel":"女"},` +
+		`"byKey":{"a":3,"b":{"sex":"1","sexLabel":"男"}},"at":"2024-01-02T00:00:00Z"}`
+	if string(b) != want {
+		t.Fatalf("输出不对:\n%s\n期望:\n%s", b, want)
+	}
+	if o.Items[0].GenderName != "" {
+		t.Fatalf("Marshal 不应改动入参: %+v", o.Items[0])
+	}
+	var m map[string]any
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatalf("输出不是合法 JSON: %v", err)
+	}
+
+	// 自定义命名、非指针入参、切片
+	b, err = Marshal(context.Background(), []marshalItem{{Sex: "1"}}, WithLabelSuffix("Text"))
+	if err != nil || string(b) != `[{"sex":"1","sexText":"男"}]` {
+		t.Fatalf("自定义后缀不对: %s %v", b, err)
+	}
+}
+
+func TestMarshalWrapperAndCycle(t *testing.T) {
+	RegisterDict("sex", map[string]string{"1": "男", "2": "女"})
+	resp := struct {
+		Code int            `json:"code"`
+		Data json.Marshaler `json:"data"`
+	}{Data: Labeled(context.Background(), &marshalItem{Sex: "2"}, WithLabelKey(strings.ToUpper))}
+	b, err := json.Marshal(resp)
+	if err != nil || string(b) != `{"code":0,"data":{"sex":"2","SEX":"女"}}` {
+		t.Fatalf("json.Marshaler 包装不对: %s %v", b, err)
+	}
+
+	n := &marshalItem{Sex: "1"}
+	n.Next = n
+	if _, err := Marshal(context.Background(), n); err == nil {
+		t.Fatalf("成环结构应返回错误")
+	}
+}
+
+type confText string
+
+func (c confText) MarshalText() ([]byte, error) { return []byte("t-" + string(c)), nil }
+
+type confZero struct{ N int }
+
+func (c confZero) IsZero() bool { return c.N < 0 }
+
+type confPtrZero struct{ N int }
+
+func (c *confPtrZero) IsZero() bool { return c.N == 1 }
+
+type ConfEmbed struct {
+	E   string `json:"e"`
+	Dup int
+}
+
+type confAll struct {
+	ConfEmbed
+	*confInner
+	Dup     string
+	HTML    string            `json:"html"`
+	Bad     string            `json:"bad"`
+	Keys    map[string]int    `json:"keys"`
+	IntKeys map[int]string    `json:"intKeys"`
+	TxtKeys map[confText]bool `json:"txtKeys"`
+	Bytes   []byte            `json:"bytes"`
+	Arr     [2]uint8          `json:"arr"`
+	Raw     json.RawMessage   `json:"raw"`
+	Any     any               `json:"any"`
+	NilPtr  *int              `json:"nilPtr"`
+	Quoted  *int              `json:"quoted,string"`
+	QStr    string            `json:"qstr,string"`
+	OmitE   []int             `json:"omitE,omitempty"`
+	OmitZ   time.Time         `json:"omitZ,omitzero"`
+	OmitZM  confZero          `json:"omitZM,omitzero"`
+	OmitZP  confPtrZero       `json:"omitZP,omitzero"`
+	OmitZS  struct{ A int }   `json:"omitZS,omitzero"`
+	Float   float64           `json:"float"`
+	At      time.Time         `json:"at"`
+}
+
+type confInner struct {
+	In string `json:"in"`
+}
+
+// 不带翻译标签时 Marshal 应与 json.Marshal 逐字节一致
+func TestMarshalConformance(t *testing.T) {
+	q := 42
+	cases := []any{
+		confAll{},
+		confAll{
+			ConfEmbed: ConfEmbed{E: "e", Dup: 1},
+			confInner: &confInner{In: "in"},
+			Dup:       "top",
+			HTML:      "<a href=\"x\">&</a> ",
+			Bad:       "ok\xffbad",
+			Keys:      map[string]int{"b\xfe": 1, "<k>": 2, "a": 3},
+			IntKeys:   map[int]string{10: "x", -2: "y"},
+			TxtKeys:   map[confText]bool{"z": true, "a": false},
+			Bytes:     []byte("hi"),
+			Arr:       [2]uint8{1, 2},
+			Raw:       json.RawMessage(`{"x":1}`),
+			Any:       map[string]any{"n": []any{1.5, "s", nil}},
+			Quoted:    &q,
+			QStr:      "say \"hi\"",
+			OmitZM:    confZero{N: -1},
+			OmitZP:    confPtrZero{N: 1},
+			OmitZS:    struct{ A int }{A: 0},
+			Float:     1e21,
+			At:        time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC),
+		},
+		&confAll{OmitZM: confZero{N: 2}, OmitZP: confPtrZero{N: 2}, OmitZS: struct{ A int }{A: 1}},
+		[]confAll{{}, {HTML: "<>"}},
+		map[string]confAll{"\xff": {}},
+		[]any{nil, "x", 1, true},
+	}
+	for i, c := range cases {
+		want, err := json.Marshal(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := Marshal(context.Background(), c)
+		if err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+		if string(got) != string(want) {
+			t.Fatalf("case %d 与 json.Marshal 不一致:\n%s\n期望:\n%s", i, got, want)
+		}
+	}
+}
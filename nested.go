@@ -0,0 +1,177 @@
+package dict
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// maxHoldDepth holdsStruct 展开容器类型的层数上限（防 type M map[string]M 这类递归类型）
+const maxHoldDepth = 8
+
+// holdsStruct 容器类型里是否可能藏着结构体：决定 map / array / 空接口 / 非结构体切片字段要不要进遍历
+func holdsStruct(t reflect.Type, depth int) bool {
+	if depth > maxHoldDepth {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		return true
+	case reflect.Interface:
+		return t.NumMethod() == 0
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return holdsStruct(t.Elem(), depth+1)
+	}
+	return false
+}
+
+// nestedKindOf 字段的遍历方式：struct / *struct / []struct / []*struct 走原来的专用路径，
+// 其余可能藏着结构体的容器（map 值、数组、空接口、[]any 等）走 translateNested
+func nestedKindOf(ft reflect.Type) nestedKind {
+	switch ft.Kind() {
+	case reflect.Struct:
+		return nestedStruct
+	case reflect.Ptr:
+		if ft.Elem().Kind() == reflect.Struct {
+			return nestedPtr
+		}
+	case reflect.Slice:
+		if et := ft.Elem(); et.Kind() == reflect.Struct || (et.Kind() == reflect.Ptr && et.Elem().Kind() == reflect.Struct) {
+			return nestedSlice
+		}
+	case reflect.Interface:
+		// 只看空接口（any）：error、context.Context 之类的接口字段不是数据
+		if ft.NumMethod() == 0 {
+			return nestedAny
+		}
+		return nestedNone
+	}
+	if holdsStruct(ft, 0) {
+		return nestedAny
+	}
+	return nestedNone
+}
+
+// translateNested 递归翻译任意容器里的结构体：指针、切片、数组、map 值、空接口（按运行时的动态类型）。
+// 不可寻址的结构体（map 的值、接口里的值）先拷贝出来翻译再写回；收集模式只收集 key，不写回。
+// map 与经接口到达的切片按引用记 visited，防止 map[string]any 自己装自己这类环
+func (dm *DictManager) translateNested(v reflect.Value, seen *walk) error {
+	switch v.Kind() {
+	case reflect.Struct:
+		if !v.CanAddr() {
+			return nil // 不可寻址的值由调用方拷贝
+		}
+		return dm.translateStructV(v, seen, false)
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Elem().Kind() == reflect.Struct {
+			return dm.translateStructV(v.Elem(), seen, true)
+		}
+		return dm.translateNested(v.Elem(), seen)
+	case reflect.Slice:
+		if v.IsNil() || !seen.markRef(v) {
+			return nil
+		}
+		return dm.translateElems(v, seen)
+	case reflect.Array:
+		if !v.CanAddr() || !holdsStruct(v.Type(), 0) {
+			return nil
+		}
+		return dm.translateElems(v, seen)
+	case reflect.Map:
+		if v.IsNil() || !holdsStruct(v.Type().Elem(), 0) || !seen.markRef(v) {
+			return nil
+		}
+		keys := v.MapKeys()
+		if seen.labels != nil {
+			sortMapKeys(keys) // 记路径时按 key 排序，与 Marshal 的输出顺序一致（共享指针记在同一条路径上）
+		}
+		for _, k := range keys {
+			val := v.MapIndex(k)
+			if seen.labels != nil {
+				seen.enter(fmt.Sprintf("[%v]", k.Interface()))
+			}
+			if cp, ok := addressableCopy(val); ok {
+				if err := dm.translateNested(cp, seen); err != nil {
+					return err
+				}
+				if seen.collect == nil {
+					v.SetMapIndex(k, cp) // 改已有 key
+				}
+			} else if err := dm.translateNested(val, seen); err != nil {
+				return err
+			}
+			if seen.labels != nil {
+				seen.leave()
+			}
+		}
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		e := v.Elem()
+		if cp, ok := addressableCopy(e); ok {
+			if err := dm.translateNested(cp, seen); err != nil {
+				return err
+			}
+			if seen.collect == nil && v.CanSet() {
+				v.Set(cp)
+			}
+			return nil
+		}
+		return dm.translateNested(e, seen)
+	}
+	return nil
+}
+
+// sortMapKeys 按 key 的字符串形式排序
+func sortMapKeys(keys []reflect.Value) {
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprintf("%v", keys[i].Interface()) < fmt.Sprintf("%v", keys[j].Interface())
+	})
+}
+
+// translateElems 逐个翻译切片 / 数组元素
+func (dm *DictManager) translateElems(v reflect.Value, seen *walk) error {
+	for i := 0; i < v.Len(); i++ {
+		if seen.labels != nil {
+			seen.enter("[" + strconv.Itoa(i) + "]")
+		}
+		if err := dm.translateNested(v.Index(i), seen); err != nil {
+			return err
+		}
+		if seen.labels != nil {
+			seen.leave()
+		}
+	}
+	return nil
+}
+
+// addressableCopy 值类型的结构体 / 数组（map 值、接口里的值不可寻址）拷贝成可寻址的副本；
+// 指针、切片、map 等引用类型原地翻译即可，返回 false
+func addressableCopy(v reflect.Value) (reflect.Value, bool) {
+	switch v.Kind() {
+	case reflect.Interface:
+		// 只拷贝动态类型是值类型结构体 / 数组的；字符串、数字不拷贝不写回，指针、map、切片按引用直接翻译
+		if v.IsNil() {
+			return v, false
+		}
+		if e := v.Elem(); (e.Kind() != reflect.Struct && e.Kind() != reflect.Array) || !holdsStruct(e.Type(), 0) {
+			return v, false
+		}
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(v)
+		return cp, true
+	case reflect.Struct, reflect.Array:
+		if !holdsStruct(v.Type(), 0) {
+			return v, false
+		}
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(v)
+		return cp, true
+	}
+	return v, false
+}
@@ -0,0 +1,141 @@
+package dict
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type nestedItem struct {
+	Sex     string `dict:"sex" dictField:"SexName"`
+	SexName string
+}
+
+func TestTranslateMapsArraysInterfaces(t *testing.T) {
+	RegisterDict("sex", map[string]string{"1": "男", "2": "女"})
+
+	type Resp struct {
+		ByID    map[string]*nestedItem
+		ByKey   map[string]nestedItem
+		Fixed   [2]nestedItem
+		Payload any
+		PtrLoad any
+		Groups  [][]nestedItem
+		Err     error
+	}
+	p := &nestedItem{Sex: "2"}
+	r := &Resp{
+		ByID:    map[string]*nestedItem{"a": {Sex: "1"}, "nil": nil},
+		ByKey:   map[string]nestedItem{"b": {Sex: "2"}},
+		Fixed:   [2]nestedItem{{Sex: "1"}, {Sex: "2"}},
+		Payload: nestedItem{Sex: "1"},
+		PtrLoad: p,
+		Groups:  [][]nestedItem{{{Sex: "2"}}},
+	}
+	if err := Translate(r); err != nil {
+		t.Fatal(err)
+	}
+	if r.ByID["a"].SexName != "男" || r.ByKey["b"].SexName != "女" {
+		t.Fatalf("map 值翻译失败: %+v %+v", r.ByID["a"], r.ByKey["b"])
+	}
+	if r.Fixed[0].SexName != "男" || r.Fixed[1].SexName != "女" {
+		t.Fatalf("数组翻译失败: %+v", r.Fixed)
+	}
+	if r.Payload.(nestedItem).SexName != "男" || p.SexName != "女" {
+		t.Fatalf("接口字段翻译失败: %+v %+v", r.Payload, p)
+	}
+	if r.Groups[0][0].SexName != "女" {
+		t.Fatalf("嵌套切片翻译失败: %+v", r.Groups)
+	}
+
+	// 顶层 map[string]any，值里有切片、结构体，且装着自己（成环）
+	doc := map[string]any{
+		"data": []any{&nestedItem{Sex: "1"}, nestedItem{Sex: "2"}},
+		"one":  nestedItem{Sex: "2"},
+	}
+	doc["self"] = doc
+	if err := Translate(&doc); err != nil {
+		t.Fatal(err)
+	}
+	data := doc["data"].([]any)
+	if data[0].(*nestedItem).SexName != "男" || data[1].(nestedItem).SexName != "女" || doc["one"].(nestedItem).SexName != "女" {
+		t.Fatalf("map[string]any 翻译失败: %+v", doc)
+	}
+}
+
+// map[string]any 里的字符串、数字不拷贝也不写回：并发只读同一个 map 时翻译不会写它
+func TestTranslateMapScalarsUntouched(t *testing.T) {
+	RegisterDict("sex", map[string]string{"1": "男"})
+	p := &nestedItem{Sex: "1"}
+	doc := map[string]any{"item": p}
+	for i := 0; i < 200; i++ {
+		doc[string(rune('a'+i%26))+string(rune('0'+i/26))] = i
+	}
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				_ = doc["a0"]
+			}
+		}
+	}()
+	for i := 0; i < 20; i++ {
+		if err := Translate(&doc); err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(stop)
+	wg.Wait()
+	if p.SexName != "男" || doc["a0"] != 0 {
+		t.Fatalf("map[string]any 翻译不对: %+v %v", p, doc["a0"])
+	}
+}
+
+// 同一底层数组的子切片（top N + 全量）各自翻译，不因数据指针相同被当成已访问
+func TestTranslateSubSlices(t *testing.T) {
+	RegisterDict("sex", map[string]string{"1": "男", "2": "女"})
+	type Resp struct {
+		Top  any
+		Data any
+	}
+	all := []nestedItem{{Sex: "1"}, {Sex: "2"}, {Sex: "1"}}
+	r := &Resp{Top: all[:1], Data: all}
+	if err := Translate(r); err != nil {
+		t.Fatal(err)
+	}
+	if all[0].SexName != "男" || all[1].SexName != "女" || all[2].SexName != "男" {
+		t.Fatalf("子切片之后的全量切片应全部翻译: %+v", all)
+	}
+}
+
+func TestTranslateMapPrefetch(t *testing.T) {
+	EnableDBCache(true)
+	ClearDBCache()
+	t.Cleanup(ClearDBCache)
+	be := &deptDB{}
+	RegisterDBTranslator(be)
+
+	type Emp struct {
+		DeptID   string `db:"table=dept,key=id,value=name" dictField:"DeptName"`
+		DeptName string
+	}
+	m := make(map[int]any, 20)
+	for i := 0; i < 20; i++ {
+		m[i] = Emp{DeptID: []string{"d1", "d2", "d4"}[i%3]}
+	}
+	if err := Translate(&m); err != nil {
+		t.Fatal(err)
+	}
+	if m[0].(Emp).DeptName != "总公司" || m[2].(Emp).DeptName != "后端组" {
+		t.Fatalf("map 值翻译失败: %+v %+v", m[0], m[2])
+	}
+	if b, s := atomic.LoadInt64(&be.batch), atomic.LoadInt64(&be.single); b != 1 || s != 0 {
+		t.Fatalf("期望 1 次批量 0 次单查，实际 batch=%d single=%d", b, s)
+	}
+}
@@ -0,0 +1,71 @@
+package dict
+
+import (
+	"context"
+	"fmt"
+)
+
+// WithDict 本次调用临时使用的内存字典：同名时覆盖已注册的，不写入全局注册表（预览、A/B 测试）。
+// dict 标签、chain / switch 里的 dict 步骤都按它查
+func WithDict(name string, dict map[string]string) Option {
+	return func(o *translateOpts) {
+		if o.overlay == nil {
+			o.overlay = &overlay{}
+		}
+		if o.overlay.dicts == nil {
+			o.overlay.dicts = make(map[string]map[string]string)
+		}
+		o.overlay.dicts[name] = dict
+	}
+}
+
+// WithTranslator 本次调用临时使用的自定义翻译器：translate:"tag" 字段改用 t（已缓存进结构体配置的也生效，
+// 没注册过的 tag 同样可用），不写入全局注册表
+func WithTranslator(tag string, t Translator) Option {
+	return func(o *translateOpts) {
+		if o.overlay == nil {
+			o.overlay = &overlay{}
+		}
+		if o.overlay.translators == nil {
+			o.overlay.translators = make(map[string]Translator)
+		}
+		o.overlay.translators[tag] = t
+		switch t.(type) {
+		case *lookupTranslator, prefetcher:
+			o.overlay.lookup = true
+		}
+	}
+}
+
+// overlay 叠在注册表上的单次调用覆盖（只读，并行 worker 共享）
+type overlay struct {
+	dicts       map[string]map[string]string
+	translators map[string]Translator
+	lookup      bool // 有 DB 类覆盖翻译器：带 translate 标签的字段也要走收集预取
+}
+
+type overlayCtxKey struct{}
+
+// withOverlay 把覆盖挂到 ctx 上，供 chain / switch 里的 dict 步骤查
+func withOverlay(ctx context.Context, ov *overlay) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, overlayCtxKey{}, ov)
+}
+
+// dictFor 先查本次调用的覆盖，再查注册表
+func (dm *DictManager) dictFor(ov *overlay, name string) map[string]string {
+	if ov != nil {
+		if d, ok := ov.dicts[name]; ok {
+			return d
+		}
+	}
+	return dm.loadReg().dicts[name]
+}
+
+// TranslateContext dict 步骤按 ctx 上的覆盖查（WithDict）
+func (d dictStep) TranslateContext(ctx context.Context, value any, _ string, tagValue string) (string, error) {
+	ov, _ := ctx.Value(overlayCtxKey{}).(*overlay)
+	return d.dm.dictFor(ov, tagValue)[fmt.Sprintf("%v", value)], nil
+}
@@ -0,0 +1,100 @@
+package dict
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+type overlayUpper struct{}
+
+func (overlayUpper) Translate(value any, _ string, _ string) (string, error) {
+	return "U-" + value.(string), nil
+}
+
+func TestWithDictOverlay(t *testing.T) {
+	RegisterDict("sex", map[string]string{"1": "男", "2": "女"})
+	type Row struct {
+		Sex       string `dict:"sex" dictField:"SexName"`
+		SexName   string
+		Kind      string `dict:"overlayOnly" dictField:"KindName"`
+		KindName  string
+		Chain     string `chain:"dict:sex | dict:overlayOnly" dictField:"ChainName"`
+		ChainName string
+	}
+	r := &Row{Sex: "1", Kind: "a", Chain: "2"}
+	err := TranslateWith(r,
+		WithDict("sex", map[string]string{"1": "Male", "2": "Female"}),
+		WithDict("overlayOnly", map[string]string{"a": "甲", "Female": "F"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.SexName != "Male" || r.KindName != "甲" || r.ChainName != "F" {
+		t.Fatalf("覆盖字典未生效: %+v", r)
+	}
+
+	// 不影响全局注册表
+	r = &Row{Sex: "1", Kind: "a"}
+	if err := Translate(r); err != nil || r.SexName != "男" || r.KindName != "" || GetDict("overlayOnly") != nil {
+		t.Fatalf("覆盖不应写入全局注册表: %v %+v", err, r)
+	}
+}
+
+func TestWithTranslatorOverlay(t *testing.T) {
+	RegisterTranslator("overlayUser", TranslatorFunc(func(value any, _ string, _ string) (string, error) {
+		return "user-" + value.(string), nil
+	}))
+	type Row struct {
+		ID        string `translate:"overlayUser" dictField:"Name"`
+		Name      string
+		Other     string `translate:"overlayNever" dictField:"OtherName"`
+		OtherName string
+	}
+	rows := make([]Row, 12)
+	for i := range rows {
+		rows[i] = Row{ID: "x", Other: "y"}
+	}
+	// 先翻译一次，让结构体配置固化注册的翻译器
+	if err := Translate(&rows[0]); err != nil || rows[0].Name != "user-x" || rows[0].OtherName != "" {
+		t.Fatalf("注册的翻译器: %v %+v", err, rows[0])
+	}
+	err := TranslateWith(&rows, WithParallel(),
+		WithTranslator("overlayUser", overlayUpper{}), WithTranslator("overlayNever", overlayUpper{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range rows {
+		if rows[i].Name != "U-x" || rows[i].OtherName != "U-y" {
+			t.Fatalf("覆盖翻译器未生效: %d %+v", i, rows[i])
+		}
+	}
+	rows[0] = Row{ID: "x", Other: "y"}
+	if err := Translate(&rows[0]); err != nil || rows[0].Name != "user-x" || rows[0].OtherName != "" {
+		t.Fatalf("覆盖不应影响后续调用: %v %+v", err, rows[0])
+	}
+}
+
+// 覆盖成 DB 类翻译器时同样按批预取，不退化为逐条查询
+func TestWithTranslatorOverlayPrefetch(t *testing.T) {
+	EnableDBCache(true)
+	ClearDBCache()
+	t.Cleanup(ClearDBCache)
+	be := &deptDB{}
+	RegisterDBTranslator(be)
+	type Row struct {
+		Dept     string `translate:"overlayDept" dictField:"DeptName"`
+		DeptName string
+	}
+	rows := make([]Row, 30)
+	for i := range rows {
+		rows[i].Dept = []string{"d1", "d2"}[i%2]
+	}
+	if err := TranslateWith(&rows, WithTranslator("overlayDept", createDBTranslator("dept", "id", "name"))); err != nil {
+		t.Fatal(err)
+	}
+	if rows[0].DeptName != "总公司" || rows[29].DeptName != "研发部" {
+		t.Fatalf("覆盖的 DB 翻译器未生效: %+v %+v", rows[0], rows[29])
+	}
+	if b, s := atomic.LoadInt64(&be.batch), atomic.LoadInt64(&be.single); b != 1 || s != 0 {
+		t.Fatalf("应批量预取一次而非逐条查询: batch=%d single=%d", b, s)
+	}
+}
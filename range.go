@@ -0,0 +1,148 @@
+package dict
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// RangeValue 区间字典支持的数值类型（time.Duration 属于 ~int64）
+type RangeValue interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Range 左闭右开区间 [From, To) 及其标签；OpenFrom / OpenTo 表示没有下界 / 上界（对应的 From / To 忽略）
+type Range[T RangeValue] struct {
+	From, To         T
+	Label            string
+	OpenFrom, OpenTo bool
+}
+
+// rangeEntry 统一成 float64 存储（整数超过 2^53 会丢精度，年龄、分数、金额、时长都够用）
+type rangeEntry struct {
+	from, to         float64
+	label            string
+	openFrom, openTo bool
+}
+
+// rangeRegistry 区间字典注册表，写时复制
+type rangeRegistry struct {
+	m  atomic.Pointer[map[string][]rangeEntry]
+	mu sync.Mutex
+}
+
+var defaultRangeRegistry = &rangeRegistry{}
+
+func (r *rangeRegistry) get(name string) []rangeEntry {
+	if m := r.m.Load(); m != nil {
+		return (*m)[name]
+	}
+	return nil
+}
+
+func (r *rangeRegistry) store(name string, entries []rangeEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var old map[string][]rangeEntry
+	if m := r.m.Load(); m != nil {
+		old = *m
+	}
+	next := make(map[string][]rangeEntry, len(old)+1)
+	for k, v := range old {
+		next[k] = v
+	}
+	next[name] = entries
+	r.m.Store(&next)
+}
+
+// RegisterRangeDict 注册区间字典（年龄段、分数段、金额档、时长档等），字段写 range:"name" 使用。
+// 区间为空（From >= To）或互相重叠时返回错误，不注册
+//
+//	dict.RegisterRangeDict("ageGroup", []dict.Range[int]{
+//		{From: 0, To: 18, Label: "未成年"},
+//		{From: 18, To: 60, Label: "成年"},
+//		{From: 60, Label: "老年", OpenTo: true},
+//	})
+func RegisterRangeDict[T RangeValue](name string, ranges []Range[T]) error {
+	entries := make([]rangeEntry, len(ranges))
+	for i, rg := range ranges {
+		e := rangeEntry{from: float64(rg.From), to: float64(rg.To), label: rg.Label, openFrom: rg.OpenFrom, openTo: rg.OpenTo}
+		if !e.openFrom && !e.openTo && e.from >= e.to {
+			return fmt.Errorf("range dict '%s': empty range [%v, %v)", name, rg.From, rg.To)
+		}
+		entries[i] = e
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].openFrom != entries[j].openFrom {
+			return entries[i].openFrom
+		}
+		return entries[i].from < entries[j].from
+	})
+	for i := 1; i < len(entries); i++ {
+		prev, cur := entries[i-1], entries[i]
+		if prev.openTo || cur.openFrom || prev.to > cur.from {
+			return fmt.Errorf("range dict '%s': %q overlaps %q", name, prev.label, cur.label)
+		}
+	}
+	defaultRangeRegistry.store(name, entries)
+	return nil
+}
+
+// rangeTranslator range 标签的翻译器：tagValue 是区间字典名
+type rangeTranslator struct{}
+
+func (rangeTranslator) Translate(value any, _ string, tagValue string) (string, error) {
+	// tagValue 是区间字典名（逗号后是 miss=nil 等字段选项）
+	name, _, _ := strings.Cut(tagValue, ",")
+	entries := defaultRangeRegistry.get(name)
+	if entries == nil {
+		return "", fmt.Errorf("range dict '%s' not found", name)
+	}
+	x, ok, err := rangeNumber(value)
+	if err != nil || !ok {
+		return "", err
+	}
+	// 找最后一个下界 <= x 的区间，再看 x 是否落在它的上界之内
+	i := sort.Search(len(entries), func(i int) bool {
+		return !entries[i].openFrom && entries[i].from > x
+	}) - 1
+	if i < 0 {
+		return "", nil
+	}
+	if e := entries[i]; e.openTo || x < e.to {
+		return e.label, nil
+	}
+	return "", nil
+}
+
+// rangeNumber 把源值转成 float64；空字符串返回 ok=false（不翻译）
+func rangeNumber(value any) (float64, bool, error) {
+	switch v := value.(type) {
+	case int64:
+		return float64(v), true, nil
+	case uint64:
+		return float64(v), true, nil
+	case string:
+		if v == "" {
+			return 0, false, nil
+		}
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil, err
+	}
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.CanInt():
+		return float64(rv.Int()), true, nil
+	case rv.CanUint():
+		return float64(rv.Uint()), true, nil
+	case rv.CanFloat():
+		return rv.Float(), true, nil
+	}
+	return 0, false, fmt.Errorf("unsupported range value type: %T", value)
+}
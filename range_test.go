@@ -0,0 +1,103 @@
+package dict
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRangeDict(t *testing.T) {
+	if err := RegisterRangeDict("ageGroup", []Range[int]{
+		{From: 18, To: 60, Label: "成年"},
+		{From: 0, To: 18, Label: "未成年"},
+		{From: 60, Label: "老年", OpenTo: true},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterRangeDict("score", []Range[float64]{
+		{Label: "不及格", To: 60, OpenFrom: true},
+		{From: 60, To: 90, Label: "及格"},
+		{From: 90, To: 100.5, Label: "优秀"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterRangeDict("wait", []Range[time.Duration]{
+		{From: 0, To: time.Minute, Label: "很快"},
+		{From: time.Minute, To: time.Hour, Label: "较慢"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	type Person struct {
+		Age       int `range:"ageGroup" dictField:"AgeGroup"`
+		AgeGroup  string
+		Score     float64 `range:"score" dictField:"Level"`
+		Level     string
+		Wait      time.Duration `range:"wait" dictField:"WaitName"`
+		WaitName  string
+		AgeText   string `range:"ageGroup" dictField:"TextGroup"`
+		TextGroup string
+	}
+	cases := []struct {
+		p                Person
+		age, level, wait string
+	}{
+		{Person{Age: 17, Score: 59.9, Wait: 59 * time.Second}, "未成年", "不及格", "很快"},
+		{Person{Age: 18, Score: 60, Wait: time.Minute}, "成年", "及格", "较慢"},
+		{Person{Age: 80, Score: 100, Wait: 2 * time.Hour}, "老年", "优秀", ""},
+		{Person{Age: -1, Score: 101, Wait: -time.Second}, "", "", ""},
+	}
+	for i, c := range cases {
+		p := c.p
+		if err := Translate(&p); err != nil {
+			t.Fatal(err)
+		}
+		if p.AgeGroup != c.age || p.Level != c.level || p.WaitName != c.wait {
+			t.Fatalf("第 %d 组区间翻译不对: %+v", i, p)
+		}
+	}
+
+	p := &Person{AgeText: "35"}
+	if err := Translate(p); err != nil || p.TextGroup != "成年" {
+		t.Fatalf("字符串数值应按数值翻译: %v %+v", err, p)
+	}
+}
+
+func TestRangeDictValidation(t *testing.T) {
+	if err := RegisterRangeDict("bad", []Range[int]{{From: 0, To: 10}, {From: 5, To: 20}}); err == nil {
+		t.Fatalf("重叠区间应报错")
+	}
+	if err := RegisterRangeDict("bad", []Range[int]{{From: 10, To: 10}}); err == nil {
+		t.Fatalf("空区间应报错")
+	}
+	if err := RegisterRangeDict("bad", []Range[int]{{From: 0, OpenTo: true}, {From: 100, To: 200}}); err == nil {
+		t.Fatalf("无上界区间后面不能再有区间")
+	}
+	if err := RegisterRangeDict("ok", []Range[int]{{From: 0, To: 10}, {From: 10, To: 20}}); err != nil {
+		t.Fatalf("相邻区间不算重叠: %v", err)
+	}
+}
+
+func TestRangeDictTagOptions(t *testing.T) {
+	if err := RegisterRangeDict("rangeOpt", []Range[int]{{From: 0, To: 18, Label: "未成年"}}); err != nil {
+		t.Fatal(err)
+	}
+	type Person struct {
+		Age      int `range:"rangeOpt,miss=nil" dictField:"AgeGroup"`
+		AgeGroup *string
+		Old      int
+		OldGroup string
+	}
+	ForManager[Person](defaultManager).Field("Old").Range("rangeOpt,miss=nil").Into("OldGroup")
+	stale := "旧值"
+	p := &Person{Age: 3, Old: 30, AgeGroup: &stale, OldGroup: "旧值"}
+	if err := Translate(p); err != nil {
+		t.Fatal(err)
+	}
+	if p.AgeGroup == nil || *p.AgeGroup != "未成年" || p.OldGroup != "" {
+		t.Fatalf("带选项的 range 标签 / 映射应按字典名查并支持 miss=nil: %+v", p)
+	}
+	p.Age = 40
+	if err := Translate(p); err != nil || p.AgeGroup != nil {
+		t.Fatalf("miss=nil 落不进区间时应清零: %v %+v", err, p)
+	}
+}
@@ -0,0 +1,210 @@
+package dict
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// WithFields 只翻译选中的字段："Status" 为顶层字段，"Items.Type" 为嵌套字段（途中的切片、指针、map 值自动展开），
+// 只写嵌套字段名（"Items"）表示其下全部字段。路径按类型解析：同一结构体类型出现在多处时选择取并集。
+// 未选中的字段不翻译、不参与批量预取；字段名写错时 TranslateWith 返回错误
+func WithFields(paths ...string) Option {
+	return func(o *translateOpts) { o.fields = append(o.fields, paths...) }
+}
+
+// WithScene 只翻译属于该场景的字段：dictScene:"list,detail" 标出字段所属场景（嵌套字段也可标），
+// 没有 dictScene 标签的字段在任何场景都翻译
+func WithScene(scene string) Option {
+	return func(o *translateOpts) { o.scene = scene }
+}
+
+// maxSelections 每个 DictManager 最多缓存的选择数：WithFields / WithScene 来自请求参数（?fields=）时组合不受控，
+// 超出后每次现解析，过滤后的遍历计划也不缓存
+const maxSelections = 1024
+
+// fieldSelection 一次调用的字段选择（解析后缓存，只读，可被并行 worker 共享）
+type fieldSelection struct {
+	scene   string                       // "" 表示不按场景筛
+	types   map[reflect.Type]*typeSelect // nil 表示不按字段筛；类型不在表里表示全选
+	cached  bool                         // 在 dm.selections 里；否则过滤后的计划只存在 configs（随本次调用释放）
+	configs sync.Map                     // 未缓存的选择：reflect.Type -> *structConfig
+}
+
+// typeSelect 一个结构体类型里选中的字段下标
+type typeSelect struct {
+	fields map[int]bool
+	all    bool
+}
+
+type selectionKey struct {
+	root reflect.Type
+	key  string
+}
+
+type selectedConfigKey struct {
+	rt  reflect.Type
+	sel *fieldSelection
+}
+
+// selection 按根类型解析本次调用的选择；无选择时返回 nil。结果按（根类型, 选择）缓存
+func (dm *DictManager) selection(root reflect.Type, o *translateOpts) (*fieldSelection, error) {
+	if len(o.fields) == 0 && o.scene == "" {
+		return nil, nil
+	}
+	paths := append([]string(nil), o.fields...)
+	sort.Strings(paths)
+	paths = slices.Compact(paths)
+	key := o.scene + "|" + strings.Join(paths, ",")
+	sk := selectionKey{root: root, key: key}
+	if s, ok := dm.selections.Load(sk); ok {
+		return s.(*fieldSelection), nil
+	}
+	sel := &fieldSelection{scene: o.scene}
+	if len(paths) > 0 {
+		sel.types = make(map[reflect.Type]*typeSelect)
+		rootStruct, ok := selectStruct(root)
+		if !ok {
+			return nil, fmt.Errorf("dict-trans: WithFields: %s is not a struct", root)
+		}
+		for _, p := range paths {
+			if err := sel.add(rootStruct, p); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if dm.selectionN.Load() >= maxSelections {
+		return sel, nil
+	}
+	sel.cached = true
+	s, loaded := dm.selections.LoadOrStore(sk, sel)
+	if !loaded {
+		dm.selectionN.Add(1)
+	}
+	return s.(*fieldSelection), nil
+}
+
+// add 沿路径登记：途经的嵌套字段与末端字段都选中；末端是嵌套字段时其结构体类型全选
+func (s *fieldSelection) add(rt reflect.Type, path string) error {
+	for _, name := range strings.Split(path, ".") {
+		i := fieldIndexByName(rt, strings.TrimSpace(name))
+		if i < 0 {
+			return fmt.Errorf("dict-trans: WithFields: %s has no field %q (path %q)", rt, name, path)
+		}
+		ts := s.types[rt]
+		if ts == nil {
+			ts = &typeSelect{fields: make(map[int]bool)}
+			s.types[rt] = ts
+		}
+		ts.fields[i] = true
+		next, ok := selectStruct(rt.Field(i).Type)
+		if !ok {
+			return nil // 到了普通字段（或 any 等运行时才知道类型的字段），其后不再细分
+		}
+		rt = next
+	}
+	// 末端是嵌套字段：其下全选
+	if ts := s.types[rt]; ts != nil {
+		ts.all = true
+	} else {
+		s.types[rt] = &typeSelect{all: true}
+	}
+	return nil
+}
+
+// selectStruct 解开指针、切片、数组、map 值，得到里面的结构体类型
+func selectStruct(t reflect.Type) (reflect.Type, bool) {
+	for i := 0; i < maxHoldDepth; i++ {
+		switch t.Kind() {
+		case reflect.Struct:
+			return t, true
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		default:
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// selected 字段是否被选中（字段路径 + 场景）
+func (s *fieldSelection) selected(rt reflect.Type, i int) bool {
+	if s.scene != "" {
+		if tag, ok := rt.Field(i).Tag.Lookup("dictScene"); ok && !inScene(tag, s.scene) {
+			return false
+		}
+	}
+	if s.types == nil {
+		return true
+	}
+	ts := s.types[rt]
+	return ts == nil || ts.all || ts.fields[i]
+}
+
+func inScene(tag, scene string) bool {
+	for _, sc := range strings.Split(tag, ",") {
+		if strings.TrimSpace(sc) == scene {
+			return true
+		}
+	}
+	return false
+}
+
+// selectedConfig 按选择过滤后的遍历计划（只删 steps，字段配置共用），按（类型, 选择）缓存（未缓存的选择不缓存）；
+// RegisterTranslator 清配置缓存时一并清掉
+func (dm *DictManager) selectedConfig(rt reflect.Type, config *structConfig, sel *fieldSelection) *structConfig {
+	if !sel.cached {
+		if c, ok := sel.configs.Load(rt); ok {
+			return c.(*structConfig)
+		}
+	}
+	k := selectedConfigKey{rt: rt, sel: sel}
+	dm.configMutex.RLock()
+	c, ok := dm.selectedCache[k]
+	dm.configMutex.RUnlock()
+	if ok {
+		return c
+	}
+
+	c = &structConfig{fields: config.fields, byIndex: config.byIndex, noop: config.noop}
+	for _, st := range config.steps {
+		if sel.selected(rt, st.index) {
+			c.steps = append(c.steps, st)
+		}
+	}
+	// 只看留下的步骤判断要不要走收集预取
+	for _, st := range c.steps {
+		if st.nested != nestedNone {
+			c.mayLookup = true
+			break
+		}
+		if st.cfg != nil {
+			if st.cfg.translateName != "" {
+				c.translate = true
+			}
+			if st.cfg.selector != nil {
+				c.mayLookup = true
+				break
+			}
+			switch st.cfg.translator.(type) {
+			case *lookupTranslator, prefetcher:
+				c.mayLookup = true
+			}
+		}
+	}
+
+	if !sel.cached {
+		sel.configs.Store(rt, c)
+		return c
+	}
+	dm.configMutex.Lock()
+	if dm.selectedCache == nil {
+		dm.selectedCache = make(map[selectedConfigKey]*structConfig)
+	}
+	dm.selectedCache[k] = c
+	dm.configMutex.Unlock()
+	return c
+}
@@ -0,0 +1,117 @@
+package dict
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+)
+
+type selectItem struct {
+	Type     string `dict:"sex" dictField:"TypeName"`
+	TypeName string
+	Dept     string `db:"table=dept,key=id,value=name" dictField:"DeptName"`
+	DeptName string
+}
+
+type selectOrder struct {
+	Sex      string `dict:"sex" dictField:"SexName" dictScene:"list,detail"`
+	SexName  string
+	Dept     string `db:"table=dept,key=id,value=name" dictField:"DeptName" dictScene:"detail"`
+	DeptName string
+	Items    []selectItem `dictScene:"detail"`
+}
+
+func TestWithFields(t *testing.T) {
+	RegisterDict("sex", map[string]string{"1": "男", "2": "女"})
+	EnableDBCache(true)
+	ClearDBCache()
+	t.Cleanup(ClearDBCache)
+	be := &deptDB{}
+	RegisterDBTranslator(be)
+
+	o := &selectOrder{Sex: "1", Dept: "d1", Items: []selectItem{{Type: "2", Dept: "d2"}}}
+	if err := TranslateWith(o, WithFields("Sex", "Items.Type")); err != nil {
+		t.Fatal(err)
+	}
+	if o.SexName != "男" || o.Items[0].TypeName != "女" {
+		t.Fatalf("选中的字段应翻译: %+v", o)
+	}
+	if o.DeptName != "" || o.Items[0].DeptName != "" || be.single+be.batch != 0 {
+		t.Fatalf("未选中的字段不应翻译也不应查库: %+v single=%d batch=%d", o, be.single, be.batch)
+	}
+
+	// 只写嵌套字段名：其下全选
+	o = &selectOrder{Sex: "1", Dept: "d1", Items: []selectItem{{Type: "2", Dept: "d2"}}}
+	if err := TranslateWith(o, WithFields("Items")); err != nil {
+		t.Fatal(err)
+	}
+	if o.SexName != "" || o.DeptName != "" || o.Items[0].TypeName != "女" || o.Items[0].DeptName != "研发部" {
+		t.Fatalf("Items 下应全选: %+v", o)
+	}
+
+	if err := TranslateWith(o, WithFields("Items.Nope")); err == nil {
+		t.Fatalf("字段名写错应返回错误")
+	}
+}
+
+func TestWithSceneSkipsPrefetch(t *testing.T) {
+	RegisterDict("sex", map[string]string{"1": "男", "2": "女"})
+	EnableDBCache(true)
+	ClearDBCache()
+	t.Cleanup(ClearDBCache)
+	be := &deptDB{}
+	RegisterDBTranslator(be)
+
+	rows := make([]*selectOrder, 20)
+	for i := range rows {
+		rows[i] = &selectOrder{Sex: "2", Dept: "d1", Items: []selectItem{{Type: "1", Dept: "d2"}}}
+	}
+	if err := TranslateWith(&rows, WithScene("list")); err != nil {
+		t.Fatal(err)
+	}
+	if rows[0].SexName != "女" || rows[0].DeptName != "" || rows[0].Items[0].TypeName != "" {
+		t.Fatalf("list 场景只应翻译 Sex: %+v", rows[0])
+	}
+	if atomic.LoadInt64(&be.single)+atomic.LoadInt64(&be.batch) != 0 {
+		t.Fatalf("list 场景不应预取 / 查库: single=%d batch=%d", be.single, be.batch)
+	}
+
+	if err := TranslateWith(&rows, WithScene("detail"), WithParallel()); err != nil {
+		t.Fatal(err)
+	}
+	if rows[19].DeptName != "总公司" || rows[19].Items[0].TypeName != "男" || rows[19].Items[0].DeptName != "研发部" {
+		t.Fatalf("detail 场景应全部翻译: %+v", rows[19])
+	}
+	// 两个 db 字段各一次批量
+	if be.batch != 2 || be.single != 0 {
+		t.Fatalf("detail 场景应按字段批量预取: single=%d batch=%d", be.single, be.batch)
+	}
+}
+
+// 选择来自请求参数时组合不受控：缓存条数封顶，超出后照常翻译
+func TestSelectionCacheBounded(t *testing.T) {
+	dm := NewDictManager()
+	dm.RegisterDict("sex", map[string]string{"1": "男", "2": "女"})
+	for i := 0; i < maxSelections+50; i++ {
+		o := &selectOrder{Sex: "1", Items: []selectItem{{Type: "2"}}}
+		if err := dm.TranslateWith(o, WithScene(fmt.Sprintf("s%d", i)), WithFields("Sex", "Sex")); err != nil {
+			t.Fatal(err)
+		}
+		if o.SexName != "" || o.Items[0].TypeName != "" {
+			t.Fatalf("未知场景只翻译没有 dictScene 的字段: %+v", o)
+		}
+	}
+	o := &selectOrder{Sex: "1", Items: []selectItem{{Type: "2"}}}
+	if err := dm.TranslateWith(o, WithScene("list"), WithFields("Sex", "Items")); err != nil || o.SexName != "男" {
+		t.Fatalf("超出上限后仍应按选择翻译: %v %+v", err, o)
+	}
+	if n := dm.selectionN.Load(); n != maxSelections {
+		t.Fatalf("选择缓存应封顶 %d，实际 %d", maxSelections, n)
+	}
+	dm.configMutex.RLock()
+	n := len(dm.selectedCache)
+	dm.configMutex.RUnlock()
+	if n > maxSelections*2 {
+		t.Fatalf("过滤后的计划缓存不应随选择无限增长: %d", n)
+	}
+}
@@ -0,0 +1,83 @@
+package dict
+
+import (
+	"database/sql/driver"
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+var (
+	valuerType        = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// needsNormalize 源字段是否要先归一化成 key：指针、接口，以及实现 driver.Valuer（sql.Null* 等）
+// 或 encoding.TextMarshaler 的类型。getOrCreateConfig 时算好，普通 string / 整数字段走快路径
+func needsNormalize(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return true
+	}
+	return t.Implements(valuerType) || t.Implements(textMarshalerType) ||
+		reflect.PointerTo(t).Implements(valuerType) || reflect.PointerTo(t).Implements(textMarshalerType)
+}
+
+// sourceKey 把源字段归一化成 key：nil 指针、无效的 sql.Null*、Valuer 出错时返回 false（跳过翻译）；
+// 整数保持 int64 / uint64（与快路径一致，枚举、区间等翻译器按数值处理），其余转成字符串
+func sourceKey(v reflect.Value) (any, bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, false
+		}
+		if v.Type().Implements(valuerType) || v.Type().Implements(textMarshalerType) {
+			break
+		}
+		v = v.Elem()
+	}
+	iface := v.Interface()
+	if !(v.Type().Implements(valuerType) || v.Type().Implements(textMarshalerType)) && v.CanAddr() {
+		iface = v.Addr().Interface() // 指针接收者的 Value / MarshalText
+	}
+	switch x := iface.(type) {
+	case driver.Valuer:
+		dv, err := x.Value()
+		if err != nil || dv == nil {
+			return nil, false
+		}
+		switch d := dv.(type) {
+		case int64, string:
+			return d, true
+		case []byte:
+			return string(d), true
+		}
+		return fmt.Sprintf("%v", dv), true
+	case encoding.TextMarshaler:
+		b, err := x.MarshalText()
+		if err != nil {
+			return nil, false
+		}
+		return string(b), true
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint(), true
+	}
+	return v.Interface(), true
+}
+
+// sourceString sourceKey 的字符串形式（dict 路径、复合键用）
+func sourceString(v reflect.Value) (string, bool) {
+	k, ok := sourceKey(v)
+	if !ok {
+		return "", false
+	}
+	if s, ok := k.(string); ok {
+		return s, true
+	}
+	return fmt.Sprintf("%v", k), true
+}
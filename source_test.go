@@ -0,0 +1,83 @@
+package dict
+
+import (
+	"database/sql"
+	"testing"
+)
+
+type skuCode struct{ prefix, n string }
+
+func (c skuCode) MarshalText() ([]byte, error) { return []byte(c.prefix + "-" + c.n), nil }
+
+func TestNullableSourceFields(t *testing.T) {
+	RegisterDict("sex", map[string]string{"1": "男", "2": "女"})
+	RegisterDict("sku", map[string]string{"A-1": "苹果"})
+	RegisterEnum("level", map[string]string{"3": "三级"})
+
+	one, three := "1", int64(3)
+	type Row struct {
+		PtrSex        *string `dict:"sex" dictField:"PtrSexName"`
+		PtrSexName    string
+		NilSex        *string `dict:"sex" dictField:"NilSexName"`
+		NilSexName    string
+		NullSex       sql.NullString `dict:"sex" dictField:"NullSexName"`
+		NullSexName   string
+		IntSex        int `dict:"sex" dictField:"IntSexName"`
+		IntSexName    string
+		Level         *int64 `enum:"level" dictField:"LevelName"`
+		LevelName     string
+		NullLevel     sql.NullInt64 `enum:"level" dictField:"NullLevelName"`
+		NullLevelName string
+		Invalid       sql.NullInt64 `enum:"level" dictField:"InvalidName"`
+		InvalidName   string
+		Sku           skuCode `dict:"sku" dictField:"SkuName"`
+		SkuName       string
+	}
+	r := &Row{
+		PtrSex:    &one,
+		NullSex:   sql.NullString{String: "2", Valid: true},
+		IntSex:    2,
+		Level:     &three,
+		NullLevel: sql.NullInt64{Int64: 3, Valid: true},
+		Invalid:   sql.NullInt64{Int64: 3},
+		Sku:       skuCode{"A", "1"},
+	}
+	if err := Translate(r); err != nil {
+		t.Fatal(err)
+	}
+	if r.PtrSexName != "男" || r.NilSexName != "" || r.NullSexName != "女" || r.IntSexName != "女" {
+		t.Fatalf("dict 路径归一化失败: %+v", r)
+	}
+	if r.LevelName != "三级" || r.NullLevelName != "三级" || r.InvalidName != "" {
+		t.Fatalf("翻译器路径归一化失败: %+v", r)
+	}
+	if r.SkuName != "苹果" {
+		t.Fatalf("TextMarshaler 源值失败: %+v", r)
+	}
+}
+
+func TestNullableSourceCollect(t *testing.T) {
+	EnableDBCache(true)
+	ClearDBCache()
+	t.Cleanup(ClearDBCache)
+	be := &deptDB{}
+	RegisterDBTranslator(be)
+
+	type Emp struct {
+		DeptID   sql.NullString `db:"table=dept,key=id,value=name" dictField:"DeptName"`
+		DeptName string
+	}
+	emps := make([]*Emp, 20)
+	for i := range emps {
+		emps[i] = &Emp{DeptID: sql.NullString{String: "d2", Valid: i%2 == 0}}
+	}
+	if err := Translate(&emps); err != nil {
+		t.Fatal(err)
+	}
+	if emps[0].DeptName != "研发部" || emps[1].DeptName != "" {
+		t.Fatalf("收集路径归一化失败: %+v %+v", emps[0], emps[1])
+	}
+	if b, s := be.batch, be.single; b != 1 || s != 0 {
+		t.Fatalf("期望 1 次批量 0 次单查，实际 batch=%d single=%d", b, s)
+	}
+}
@@ -0,0 +1,93 @@
+package dict
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// maxDynSteps @ 简写每个字段最多缓存的来源数：兄弟字段是自由文本时不无限增长，超出的值每次现建（不参与按字典分组预取）
+const maxDynSteps = 256
+
+// fieldSelector 多态字典：按兄弟字段的值为每个元素选翻译来源。两种写法：
+//   - 简写 dictTable:"@TargetType"（dict / enum / dictTable / dictTableTwo 通用）：字典名就是兄弟字段的值
+//   - switch:"TargetType; user => db:table=user,key=id,value=name; device => dictTable:device; default => dict:unknown"：
+//     按值映射到来源，来源写法同 chain 的步骤；没有匹配也没有 default 时跳过
+type fieldSelector struct {
+	index int                  // 兄弟字段下标
+	cases map[string]chainStep // switch：值 -> 来源
+	def   *chainStep           // switch 的 default 分支
+	kind  string               // @ 简写的来源类型
+	dm    *DictManager
+	dyn   sync.Map // @ 简写：值 -> chainStep，惰性创建；同一字典共用一个翻译器，收集模式才能按字典分组
+	dynN  atomic.Int32
+}
+
+// newAtSelector 解析 @Field 简写；字段不存在或未导出返回 nil
+func (dm *DictManager) newAtSelector(rt reflect.Type, kind, name string) *fieldSelector {
+	idx := fieldIndexByName(rt, strings.TrimPrefix(name, "@"))
+	if idx < 0 || !rt.Field(idx).IsExported() {
+		return nil
+	}
+	return &fieldSelector{index: idx, kind: kind, dm: dm}
+}
+
+// parseSwitchTag 解析 switch 标签：第一段是兄弟字段名（可带 @），其后每段 "值 => 来源"
+func (dm *DictManager) parseSwitchTag(rt reflect.Type, tag string) *fieldSelector {
+	parts := strings.Split(tag, ";")
+	idx := fieldIndexByName(rt, strings.TrimPrefix(strings.TrimSpace(parts[0]), "@"))
+	if idx < 0 || !rt.Field(idx).IsExported() || len(parts) < 2 {
+		return nil
+	}
+	s := &fieldSelector{index: idx, cases: make(map[string]chainStep, len(parts)-1), dm: dm}
+	for _, part := range parts[1:] {
+		value, source, ok := strings.Cut(part, "=>")
+		if !ok {
+			return nil
+		}
+		st, ok := dm.parseStep(source)
+		if !ok {
+			return nil
+		}
+		if value = strings.TrimSpace(value); value == "default" {
+			s.def = &st
+		} else {
+			s.cases[value] = st
+		}
+	}
+	return s
+}
+
+// pick 取当前元素要用的来源；兄弟字段为空或没有匹配分支时返回 false。
+// 兄弟字段按源字段的规则归一化（指针、sql.Null*、Valuer 等取其值）
+func (s *fieldSelector) pick(structValue reflect.Value) (chainStep, bool) {
+	value, _ := sourceString(structValue.Field(s.index))
+	if s.cases != nil {
+		if st, ok := s.cases[value]; ok {
+			return st, true
+		}
+		if s.def != nil {
+			return *s.def, true
+		}
+		return chainStep{}, false
+	}
+	if value == "" {
+		return chainStep{}, false
+	}
+	if st, ok := s.dyn.Load(value); ok {
+		return st.(chainStep), true
+	}
+	st, ok := s.dm.parseStep(s.kind + ":" + value)
+	if !ok {
+		return chainStep{}, false
+	}
+	if s.dynN.Load() >= maxDynSteps {
+		return st, true
+	}
+	actual, loaded := s.dyn.LoadOrStore(value, st)
+	if !loaded {
+		s.dynN.Add(1)
+	}
+	return actual.(chainStep), true
+}
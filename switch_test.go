@@ -0,0 +1,142 @@
+package dict
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSwitchSelectsSourceBySibling(t *testing.T) {
+	EnableDBCache(true)
+	ClearDBCache()
+	t.Cleanup(ClearDBCache)
+	be := &deptDB{}
+	RegisterDBTranslator(be)
+	RegisterDict("kind_unknown", map[string]string{"x1": "未知对象"})
+
+	type Audit struct {
+		TargetType string
+		TargetID   string `switch:"TargetType; dept => db:table=dept,key=id,value=name; other => dict:kind_unknown" dictField:"TargetName"`
+		TargetName string
+	}
+	list := make([]Audit, 20)
+	for i := range list {
+		list[i] = Audit{TargetType: "dept", TargetID: []string{"d1", "d2"}[i%2]}
+	}
+	list[3] = Audit{TargetType: "other", TargetID: "x1"}
+	list[5] = Audit{TargetType: "missing", TargetID: "d1"}
+	if err := Translate(&list); err != nil {
+		t.Fatal(err)
+	}
+	if list[0].TargetName != "总公司" || list[1].TargetName != "研发部" || list[3].TargetName != "未知对象" {
+		t.Fatalf("按类型选来源失败: %+v", list[:4])
+	}
+	if list[5].TargetName != "" {
+		t.Fatalf("没有匹配分支应跳过: %+v", list[5])
+	}
+	if b, s := atomic.LoadInt64(&be.batch), atomic.LoadInt64(&be.single); b != 1 || s != 0 {
+		t.Fatalf("同一来源应只批量一次，实际 batch=%d single=%d", b, s)
+	}
+
+	// default 分支
+	type WithDefault struct {
+		Kind string
+		ID   string `switch:"Kind; dept => db:table=dept,key=id,value=name; default => dict:kind_unknown" dictField:"Name"`
+		Name string
+	}
+	w := &WithDefault{Kind: "whatever", ID: "x1"}
+	if err := Translate(w); err != nil || w.Name != "未知对象" {
+		t.Fatalf("default 分支失败: %v %+v", err, w)
+	}
+}
+
+func TestAtFieldDictName(t *testing.T) {
+	ClearDBCache()
+	t.Cleanup(ClearDBCache)
+	var calls int64
+	RegisterDictTableTranslator(DictTableTranslatorFunc(func(dictType, dictKey string) (string, error) {
+		atomic.AddInt64(&calls, 1)
+		return dictType + "/" + dictKey, nil
+	}))
+	RegisterDict("color", map[string]string{"r": "红"})
+	RegisterDict("size", map[string]string{"l": "大"})
+	RegisterEnum("level", map[string]string{"1": "一级"})
+
+	type Attr struct {
+		Type      string
+		Value     string `dict:"@Type" dictField:"Label"`
+		Label     string
+		TableType string
+		Code      string `dictTable:"@TableType" dictField:"CodeName"`
+		CodeName  string
+		EnumName  string
+		EnumValue int `enum:"@EnumName" dictField:"EnumLabel"`
+		EnumLabel string
+	}
+	a := []Attr{
+		{Type: "color", Value: "r", TableType: "gender", Code: "1", EnumName: "level", EnumValue: 1},
+		{Type: "size", Value: "l", TableType: "status", Code: "2"},
+		{Type: "", Value: "r"},
+	}
+	if err := Translate(&a); err != nil {
+		t.Fatal(err)
+	}
+	if a[0].Label != "红" || a[1].Label != "大" || a[2].Label != "" {
+		t.Fatalf("dict @Field 失败: %+v", a)
+	}
+	if a[0].CodeName != "gender/1" || a[1].CodeName != "status/2" || a[2].CodeName != "" {
+		t.Fatalf("dictTable @Field 失败: %+v", a)
+	}
+	if a[0].EnumLabel != "一级" || a[1].EnumLabel != "" {
+		t.Fatalf("enum @Field 失败: %+v", a)
+	}
+
+	// 兄弟字段不存在：标签忽略
+	type Bad struct {
+		Value string `dict:"@Nope" dictField:"Label"`
+		Label string
+	}
+	b := &Bad{Value: "r"}
+	if err := Translate(b); err != nil || b.Label != "" {
+		t.Fatalf("兄弟字段不存在应忽略: %v %+v", err, b)
+	}
+}
+
+func TestSwitchNormalizesSibling(t *testing.T) {
+	RegisterDict("kind_a", map[string]string{"1": "甲"})
+	RegisterDict("color", map[string]string{"r": "红"})
+	kind := "a"
+	type Row struct {
+		Kind     *string
+		Code     string `switch:"Kind; a => dict:kind_a" dictField:"CodeName"`
+		CodeName string
+		Dict     sql.NullString
+		Value    string `dict:"@Dict" dictField:"Label"`
+		Label    string
+	}
+	r := &Row{Kind: &kind, Code: "1", Dict: sql.NullString{String: "color", Valid: true}, Value: "r"}
+	if err := Translate(r); err != nil || r.CodeName != "甲" || r.Label != "红" {
+		t.Fatalf("指针 / sql.Null* 兄弟字段应按值匹配: %v %+v", err, r)
+	}
+
+	// 兄弟字段是自由文本：缓存的来源数有上限
+	type Free struct {
+		Name  string
+		Value string `dict:"@Name" dictField:"Label"`
+		Label string
+	}
+	rows := make([]Free, maxDynSteps+100)
+	for i := range rows {
+		rows[i] = Free{Name: fmt.Sprintf("free%d", i), Value: "r"}
+	}
+	rows[len(rows)-1].Name = "color"
+	if err := Translate(&rows); err != nil || rows[len(rows)-1].Label != "红" {
+		t.Fatalf("超出缓存上限后仍应翻译: %v %+v", err, rows[len(rows)-1])
+	}
+	cfg := defaultManager.getOrCreateConfig(reflect.TypeOf(Free{}))
+	if n := cfg.byIndex[1].selector.dynN.Load(); n != maxDynSteps {
+		t.Fatalf("缓存的来源数应封顶 %d，实际 %d", maxDynSteps, n)
+	}
+}
@@ -1,5 +1,13 @@
 package dict
 
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
 // TableConfig 表结构配置
 // 用于自定义字典表的表结构
 type TableConfig struct {
@@ -14,6 +22,61 @@ type TableConfig struct {
 
 	// 排序字段（可选）
 	SortField string
+
+	// 按键翻译时对停用项的处理（默认 StatusEnabledOnly）；列表类查询（BuildQuery / BuildQueryAll / BuildQueryAllTypes）始终只取启用项
+	StatusPolicy StatusPolicy
+
+	// StatusMarkDisabled 时追加在停用项标签后的文字，空则用 DefaultDisabledSuffix
+	DisabledSuffix string
+
+	// 上级字段（可选，树形字典）：配置后 SQL 后端支持 tree:"...,source=dictTable"，按层向上取祖先
+	ParentField string
+
+	// 额外过滤条件（可选）：每条查询都会带上，如 del_flag = '0'、app_code = 'crm'
+	ExtraConditions []Condition
+
+	// 从 ctx 派生的过滤条件（可选）：如租户 ID，查询时从 TranslateWith(WithContext(ctx)) 的 ctx 取值；
+	// 取出的值同时并入结果缓存键，不同租户的结果互不可见
+	ContextConditions []ContextCondition
+
+	// 生效区间字段（可选）：配置 ValidFromField 后只取 valid_from <= 日期 且（valid_to 为 NULL 或 valid_to > 日期）的记录。
+	// 日期取自 ctx 的 as-of 日期（标签选项 asOf=字段名 或 ContextWithAsOf），没有时用当前时间
+	ValidFromField string
+	ValidToField   string
+
+	// as-of 日期的精度（默认 0 = 按天，取该时区当天零点）：查询与缓存键都用截断后的日期，同一天的单据共享缓存
+	ValidityPrecision time.Duration
+
+	asOf time.Time // Resolve 时从 ctx 取出并截断；零值表示用当前时间
+}
+
+// StatusPolicy 按键翻译时如何对待已停用的字典项
+type StatusPolicy int
+
+const (
+	// StatusEnabledOnly 只翻译启用项（默认）
+	StatusEnabledOnly StatusPolicy = iota
+	// StatusIncludeDisabled 停用项也翻译，历史记录不丢标签
+	StatusIncludeDisabled
+	// StatusMarkDisabled 停用项也翻译并标记为停用：标签后追加 DisabledSuffix，
+	// 或写入标签选项 disabledField= 指定的 bool 字段（此时标签不加后缀）
+	StatusMarkDisabled
+)
+
+// DefaultDisabledSuffix StatusMarkDisabled 未配置 DisabledSuffix 时的默认后缀
+const DefaultDisabledSuffix = "（已停用）"
+
+// Condition 静态过滤条件：Field = Value
+type Condition struct {
+	Field string
+	Value any
+}
+
+// ContextCondition 从 ctx 派生的过滤条件：Field = Value(ctx)。
+// Value 返回 nil 时条件仍会加上（SQL 里 = NULL 恒不成立），缺租户时查不到数据而不是查到所有租户的数据。
+type ContextCondition struct {
+	Field string
+	Value func(ctx context.Context) any
 }
 
 // TableFields 表字段映射
@@ -102,21 +165,13 @@ func (tc *TableConfig) BuildQuery(dictType string) (string, []any) {
 		args = append(args, dictType)
 	}
 
-	// 添加状态条件（如果配置了状态字段）
-	if tc.StatusField != nil {
-		if len(args) > 0 {
-			query += " AND "
-		}
-		query += tc.StatusField.FieldName + " = ?"
-		args = append(args, tc.StatusField.EnabledValue)
-	}
-
-	return query, args
+	// 添加状态条件与额外过滤条件
+	return tc.appendFilters(query, args)
 }
 
-// BuildQueryWithKey 构建带键的查询 SQL
+// BuildQueryWithKey 构建带键的查询 SQL（StatusMarkDisabled 时多选出状态字段：SELECT value, status）
 func (tc *TableConfig) BuildQueryWithKey(dictType, dictKey string) (string, []any) {
-	query := "SELECT " + tc.Fields.ValueField + " FROM " + tc.TableName + " WHERE "
+	query := "SELECT " + tc.Fields.ValueField + tc.statusColumn() + " FROM " + tc.TableName + " WHERE "
 	args := []any{}
 
 	// 添加类型条件（如果有）
@@ -134,16 +189,8 @@ func (tc *TableConfig) BuildQueryWithKey(dictType, dictKey string) (string, []an
 		args = append(args, dictKey)
 	}
 
-	// 添加状态条件（如果配置了状态字段）
-	if tc.StatusField != nil {
-		if len(args) > 0 {
-			query += " AND "
-		}
-		query += tc.StatusField.FieldName + " = ?"
-		args = append(args, tc.StatusField.EnabledValue)
-	}
-
-	return query, args
+	// 添加状态条件（按 StatusPolicy）与额外过滤条件
+	return tc.appendLookupFilters(query, args)
 }
 
 // BuildTypeCheckQuery 构建类型检查查询（用于双表字典）
@@ -157,16 +204,8 @@ func (tc *TableConfig) BuildTypeCheckQuery(dictTypeCode string) (string, []any)
 		args = append(args, dictTypeCode)
 	}
 
-	// 添加状态条件（如果配置了状态字段）
-	if tc.StatusField != nil {
-		if len(args) > 0 {
-			query += " AND "
-		}
-		query += tc.StatusField.FieldName + " = ?"
-		args = append(args, tc.StatusField.EnabledValue)
-	}
-
-	return query, args
+	// 添加状态条件与额外过滤条件
+	return tc.appendFilters(query, args)
 }
 
 // BuildQueryAll 构建"取某类型全部 key/value"的查询：SELECT key, value FROM t WHERE type = ? [AND status = ?]
@@ -177,22 +216,54 @@ func (tc *TableConfig) BuildQueryAll(dictType string) (string, []any) {
 		query += tc.Fields.TypeField + " = ?"
 		args = append(args, dictType)
 	}
-	if tc.StatusField != nil {
-		if len(args) > 0 {
-			query += " AND "
-		}
-		query += tc.StatusField.FieldName + " = ?"
-		args = append(args, tc.StatusField.EnabledValue)
+	return tc.appendFilters(query, args)
+}
+
+// BuildQueryAllTypes 构建"一次取全部字典类型"的查询：SELECT type, key, value FROM t [WHERE status = ? ...]
+func (tc *TableConfig) BuildQueryAllTypes() (string, []any) {
+	query := "SELECT " + tc.Fields.TypeField + ", " + tc.Fields.KeyField + ", " + tc.Fields.ValueField + " FROM " + tc.TableName
+	return tc.appendWhere(query)
+}
+
+// BuildTypeListQuery 构建"列出启用的字典类型"的查询：SELECT type FROM t [WHERE status = ? ...]（用于双表字典）
+func (tc *TableConfig) BuildTypeListQuery() (string, []any) {
+	return tc.appendWhere("SELECT " + tc.Fields.TypeField + " FROM " + tc.TableName)
+}
+
+// BuildQueryNodes 构建树形字典的节点查询：SELECT key, value, parent FROM t WHERE type = ? AND key IN (?, ...) [AND status = ?]
+func (tc *TableConfig) BuildQueryNodes(dictType string, dictKeys []string) (string, []any) {
+	if len(dictKeys) == 0 {
+		return "", nil
 	}
-	return query, args
+	query := "SELECT " + tc.Fields.KeyField + ", " + tc.Fields.ValueField + ", " + tc.ParentField + " FROM " + tc.TableName + " WHERE "
+	args := make([]any, 0, len(dictKeys)+2)
+	if tc.Fields.TypeField != "" {
+		query += tc.Fields.TypeField + " = ? AND "
+		args = append(args, dictType)
+	}
+	query += tc.Fields.KeyField + " IN (?" + strings.Repeat(", ?", len(dictKeys)-1) + ")"
+	for _, k := range dictKeys {
+		args = append(args, k)
+	}
+	return tc.appendLookupFilters(query, args)
+}
+
+// appendWhere 只有过滤条件、没有类型 / 键条件的查询：有条件才加 WHERE
+func (tc *TableConfig) appendWhere(query string) (string, []any) {
+	filters, args := tc.appendFilters("", nil)
+	if len(args) == 0 {
+		return query, nil
+	}
+	return query + " WHERE " + filters, args
 }
 
 // BuildQueryIn 构建批量查询：SELECT key, value FROM t WHERE type = ? AND key IN (?, ?, ...) [AND status = ?]
+// （StatusMarkDisabled 时为 SELECT key, value, status）
 func (tc *TableConfig) BuildQueryIn(dictType string, dictKeys []string) (string, []any) {
 	if len(dictKeys) == 0 {
 		return "", nil // 没有 key 就没有查询；调用方应直接返回空结果
 	}
-	query := "SELECT " + tc.Fields.KeyField + ", " + tc.Fields.ValueField + " FROM " + tc.TableName + " WHERE "
+	query := "SELECT " + tc.Fields.KeyField + ", " + tc.Fields.ValueField + tc.statusColumn() + " FROM " + tc.TableName + " WHERE "
 	args := make([]any, 0, len(dictKeys)+2)
 	if tc.Fields.TypeField != "" {
 		query += tc.Fields.TypeField + " = ?"
@@ -210,9 +281,150 @@ func (tc *TableConfig) BuildQueryIn(dictType string, dictKeys []string) (string,
 		args = append(args, k)
 	}
 	query += ")"
-	if tc.StatusField != nil {
-		query += " AND " + tc.StatusField.FieldName + " = ?"
-		args = append(args, tc.StatusField.EnabledValue)
+	return tc.appendLookupFilters(query, args)
+}
+
+// appendFilters 追加状态条件（如果配置了状态字段）、ExtraConditions 与 ContextConditions。
+// 未经 Resolve 的配置按 context.Background() 取 ContextConditions 的值（通常为 nil，查不到数据）。
+func (tc *TableConfig) appendFilters(query string, args []any) (string, []any) {
+	return tc.filters(query, args, true)
+}
+
+// appendLookupFilters 按键查询的过滤条件：StatusPolicy 不是 StatusEnabledOnly 时不加状态条件
+func (tc *TableConfig) appendLookupFilters(query string, args []any) (string, []any) {
+	return tc.filters(query, args, tc.StatusPolicy == StatusEnabledOnly)
+}
+
+func (tc *TableConfig) filters(query string, args []any, enabledOnly bool) (string, []any) {
+	and := func(cond string, values ...any) {
+		if len(args) > 0 {
+			query += " AND "
+		}
+		query += cond
+		args = append(args, values...)
+	}
+	if tc.StatusField != nil && enabledOnly {
+		and(tc.StatusField.FieldName+" = ?", tc.StatusField.EnabledValue)
+	}
+	for _, c := range tc.ExtraConditions {
+		and(c.Field+" = ?", c.Value)
+	}
+	for _, c := range tc.ContextConditions {
+		and(c.Field+" = ?", c.Value(context.Background()))
+	}
+	if tc.ValidFromField != "" {
+		at := tc.asOf
+		if at.IsZero() {
+			at = tc.truncateAsOf(time.Now())
+		}
+		and(tc.ValidFromField+" <= ?", at)
+		if tc.ValidToField != "" {
+			and("("+tc.ValidToField+" IS NULL OR "+tc.ValidToField+" > ?)", at)
+		}
 	}
 	return query, args
 }
+
+// truncateAsOf 按 ValidityPrecision 截断 as-of 日期（默认按天）
+func (tc *TableConfig) truncateAsOf(t time.Time) time.Time {
+	if tc.ValidityPrecision <= 0 {
+		y, m, d := t.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	}
+	return t.Truncate(tc.ValidityPrecision)
+}
+
+// asOfFor ctx 下生效的（截断后的）as-of 日期
+func (tc *TableConfig) asOfFor(ctx context.Context) time.Time {
+	if t, ok := AsOf(ctx); ok {
+		return tc.truncateAsOf(t)
+	}
+	return tc.truncateAsOf(time.Now())
+}
+
+// Resolve 按 ctx 求出 ContextConditions 的值与 as-of 日期，返回一份把它们固化下来的副本；
+// 两者都没配置时原样返回（零分配）。SQL 后端每次查询前调用。
+func (tc *TableConfig) Resolve(ctx context.Context) *TableConfig {
+	if len(tc.ContextConditions) == 0 && tc.ValidFromField == "" {
+		return tc
+	}
+	out := *tc
+	if tc.ValidFromField != "" {
+		out.asOf = tc.asOfFor(ctx)
+	}
+	if len(tc.ContextConditions) == 0 {
+		return &out
+	}
+	out.ExtraConditions = make([]Condition, 0, len(tc.ExtraConditions)+len(tc.ContextConditions))
+	out.ExtraConditions = append(out.ExtraConditions, tc.ExtraConditions...)
+	for _, c := range tc.ContextConditions {
+		out.ExtraConditions = append(out.ExtraConditions, Condition{Field: c.Field, Value: c.Value(ctx)})
+	}
+	out.ContextConditions = nil
+	return &out
+}
+
+// cacheScope ContextConditions 在 ctx 下的取值与生效版本（截断后的 as-of 日期），拼成结果缓存键的作用域；都没有时为空串
+func (tc *TableConfig) cacheScope(ctx context.Context) string {
+	if len(tc.ContextConditions) == 0 && tc.ValidFromField == "" {
+		return ""
+	}
+	parts := make([]string, 0, len(tc.ContextConditions)+1)
+	for _, c := range tc.ContextConditions {
+		parts = append(parts, c.Field+"="+fmt.Sprint(c.Value(ctx)))
+	}
+	if tc.ValidFromField != "" {
+		parts = append(parts, "asOf="+tc.asOfFor(ctx).Format(time.RFC3339))
+	}
+	return strings.Join(parts, "\x00")
+}
+
+type asOfKey struct{}
+
+// ContextWithAsOf 在 ctx 上挂 as-of 日期：带生效区间的字典（TableConfig.ValidFromField）按该日期取标签。
+// 标签选项 asOf=字段名 会按字段值为每个字段自动设置；整次调用固定一个日期时用 WithContext(ContextWithAsOf(ctx, t))。
+func ContextWithAsOf(ctx context.Context, t time.Time) context.Context {
+	return context.WithValue(ctx, asOfKey{}, t)
+}
+
+// AsOf 取出 ctx 上的 as-of 日期（自定义后端实现时间版本字典时用）
+func AsOf(ctx context.Context) (time.Time, bool) {
+	if ctx == nil {
+		return time.Time{}, false
+	}
+	t, ok := ctx.Value(asOfKey{}).(time.Time)
+	return t, ok
+}
+
+// marksDisabled 按键查询是否多选出状态字段用于标记停用项
+func (tc *TableConfig) marksDisabled() bool {
+	return tc.StatusPolicy == StatusMarkDisabled && tc.StatusField != nil
+}
+
+// statusColumn StatusMarkDisabled 时追加到 SELECT 列表的状态字段
+func (tc *TableConfig) statusColumn() string {
+	if !tc.marksDisabled() {
+		return ""
+	}
+	return ", " + tc.StatusField.FieldName
+}
+
+// markIfDisabled 状态值表示停用时给标签打上停用标记（见 MarkDisabled）。
+// 配置了 DisabledValue 时以它判定，否则"不等于 EnabledValue"即停用；状态列为 NULL 视为启用。
+func (tc *TableConfig) markIfDisabled(label string, status sql.NullString) string {
+	if !status.Valid {
+		return label
+	}
+	disabled := status.String != tc.StatusField.EnabledValue
+	if tc.StatusField.DisabledValue != "" {
+		disabled = status.String == tc.StatusField.DisabledValue
+	}
+	if !disabled {
+		return label
+	}
+	suffix := tc.DisabledSuffix
+	if suffix == "" {
+		suffix = DefaultDisabledSuffix
+	}
+	return MarkDisabled(label, suffix)
+}
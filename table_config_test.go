@@ -1,7 +1,11 @@
 package dict
 
 import (
+	"context"
+	"database/sql"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestTableConfig_BuildQuery(t *testing.T) {
@@ -87,3 +91,85 @@ func TestTableConfig_NoStatusField(t *testing.T) {
 		t.Errorf("Expected 2 args (no status), got %d", len(args))
 	}
 }
+
+type tenantKey struct{}
+
+func TestTableConfig_ExtraAndContextConditions(t *testing.T) {
+	config := DefaultTableConfig("sys_dict")
+	config.ExtraConditions = []Condition{{Field: "del_flag", Value: "0"}, {Field: "app_code", Value: "crm"}}
+	config.ContextConditions = []ContextCondition{{Field: "tenant_id", Value: func(ctx context.Context) any { return ctx.Value(tenantKey{}) }}}
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, "t1")
+	query, args := config.Resolve(ctx).BuildQueryWithKey("sex", "1")
+	expectedQuery := "SELECT dict_value FROM sys_dict WHERE dict_type = ? AND dict_key = ? AND status = ? AND del_flag = ? AND app_code = ? AND tenant_id = ?"
+	if query != expectedQuery {
+		t.Errorf("Expected query '%s', got '%s'", expectedQuery, query)
+	}
+	if len(args) != 6 || args[3] != "0" || args[4] != "crm" || args[5] != "t1" {
+		t.Errorf("Unexpected args: %v", args)
+	}
+
+	// 未 Resolve：ctx 条件仍在，值取自 context.Background()（nil），不会漏掉租户过滤
+	query, args = config.BuildQueryIn("sex", []string{"1", "2"})
+	if !strings.HasSuffix(query, "dict_key IN (?, ?) AND status = ? AND del_flag = ? AND app_code = ? AND tenant_id = ?") || args[len(args)-1] != nil {
+		t.Errorf("BuildQueryIn: %s %v", query, args)
+	}
+
+	if s := config.cacheScope(ctx); s != "tenant_id=t1" {
+		t.Errorf("Expected cache scope 'tenant_id=t1', got '%s'", s)
+	}
+	if DefaultTableConfig("sys_dict").Resolve(ctx) == nil || DefaultTableConfig("x").cacheScope(ctx) != "" {
+		t.Error("没有 ContextConditions 时 Resolve 原样返回、作用域为空")
+	}
+}
+
+func TestTableConfig_StatusPolicy(t *testing.T) {
+	config := DefaultTableConfig("sys_dict")
+	config.StatusPolicy = StatusIncludeDisabled
+	query, args := config.BuildQueryWithKey("sex", "1")
+	if query != "SELECT dict_value FROM sys_dict WHERE dict_type = ? AND dict_key = ?" || len(args) != 2 {
+		t.Errorf("IncludeDisabled 按键查询不应带状态条件: %s %v", query, args)
+	}
+	// 列表类查询仍只取启用项
+	if query, _ := config.BuildQueryAll("sex"); !strings.HasSuffix(query, "status = ?") {
+		t.Errorf("BuildQueryAll 应只取启用项: %s", query)
+	}
+
+	config.StatusPolicy = StatusMarkDisabled
+	query, _ = config.BuildQueryIn("sex", []string{"1"})
+	if query != "SELECT dict_key, dict_value, status FROM sys_dict WHERE dict_type = ? AND dict_key IN (?)" {
+		t.Errorf("MarkDisabled 应多选出状态字段: %s", query)
+	}
+	if v := config.markIfDisabled("男", sql.NullString{String: "0", Valid: true}); v != MarkDisabled("男", DefaultDisabledSuffix) {
+		t.Errorf("状态 0 应标记为停用: %q", v)
+	}
+	if v := config.markIfDisabled("男", sql.NullString{String: "1", Valid: true}); v != "男" {
+		t.Errorf("启用项不应标记: %q", v)
+	}
+	if v := config.markIfDisabled("男", sql.NullString{}); v != "男" {
+		t.Errorf("状态为 NULL 应视为启用: %q", v)
+	}
+}
+
+func TestTableConfig_Validity(t *testing.T) {
+	config := DefaultTableConfig("sys_dict")
+	config.ValidFromField, config.ValidToField = "valid_from", "valid_to"
+
+	at := time.Date(2024, 6, 1, 15, 30, 0, 0, time.UTC)
+	ctx := ContextWithAsOf(context.Background(), at)
+	query, args := config.Resolve(ctx).BuildQueryWithKey("category", "A")
+	if !strings.HasSuffix(query, "status = ? AND valid_from <= ? AND (valid_to IS NULL OR valid_to > ?)") || len(args) != 5 {
+		t.Fatalf("生效区间条件不对: %s %v", query, args)
+	}
+	day := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	if args[3] != day || args[4] != day {
+		t.Errorf("as-of 日期应按天截断: %v", args[3:])
+	}
+	if s := config.cacheScope(ctx); s != "asOf=2024-06-01T00:00:00Z" {
+		t.Errorf("缓存作用域应含生效版本: %q", s)
+	}
+	// 同一天的不同时刻共享作用域
+	if config.cacheScope(ContextWithAsOf(context.Background(), day.Add(time.Hour))) != config.cacheScope(ctx) {
+		t.Error("同一天应共享缓存作用域")
+	}
+}
@@ -0,0 +1,108 @@
+package dict
+
+import (
+	"database/sql"
+	"encoding"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DictItemSetter 结构体目标字段可实现它自行填充（如按编码补颜色）。未实现时按字段名填：
+// Code / Key 写源值，Text / Label / Name 写标签
+type DictItemSetter interface {
+	SetDictItem(code, label string)
+}
+
+var stringType = reflect.TypeOf("")
+
+// setTarget 把翻译结果写入目标字段，支持 string 及自定义 string 类型、指针（新分配）、
+// sql.Scanner（sql.NullString 等）、encoding.TextUnmarshaler 和结构体；code 是源值，只有结构体目标用到。
+// 目标不可设置或类型不支持时返回 false
+func setTarget(target reflect.Value, label string, code any) bool {
+	if !target.CanSet() {
+		return false
+	}
+	if target.Type() == stringType {
+		target.SetString(label)
+		return true
+	}
+	if target.Kind() == reflect.Ptr {
+		elem := reflect.New(target.Type().Elem())
+		if !setTarget(elem.Elem(), label, code) {
+			return false
+		}
+		target.Set(elem)
+		return true
+	}
+	switch t := target.Addr().Interface().(type) {
+	case DictItemSetter:
+		t.SetDictItem(codeString(code), label)
+		return true
+	case sql.Scanner:
+		return t.Scan(label) == nil
+	case encoding.TextUnmarshaler:
+		return t.UnmarshalText([]byte(label)) == nil
+	}
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(label)
+		return true
+	case reflect.Struct:
+		set := false
+		rt := target.Type()
+		for i := 0; i < rt.NumField(); i++ {
+			if !rt.Field(i).IsExported() {
+				continue
+			}
+			switch strings.ToLower(rt.Field(i).Name) {
+			case "code", "key":
+				set = setTarget(target.Field(i), codeString(code), nil) || set
+			case "text", "label", "name":
+				set = setTarget(target.Field(i), label, nil) || set
+			}
+		}
+		return set
+	}
+	return false
+}
+
+// clearTarget 翻译缺失且配置了 miss=nil 时把目标置零：指针为 nil，sql.NullString 为无效，string 为空
+func clearTarget(target reflect.Value) {
+	if target.CanSet() {
+		target.Set(reflect.Zero(target.Type()))
+	}
+}
+
+func codeString(code any) string {
+	if s, ok := code.(string); ok {
+		return s
+	}
+	if code == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", code)
+}
+
+// miss 本元素没有翻译结果（源值为空 / 无效、来源缺失、查不到）：配置了 miss=nil 时清空目标字段
+func (fc *fieldConfig) miss(structValue reflect.Value) error {
+	if !fc.clearOnMiss {
+		return nil
+	}
+	if fc.targetFieldIndex >= 0 {
+		clearTarget(structValue.Field(fc.targetFieldIndex))
+	}
+	for _, i := range fc.targetIndexes {
+		if i >= 0 {
+			clearTarget(structValue.Field(i))
+		}
+	}
+	return nil
+}
+
+// write 写 dictField 指定的目标字段（下标在配置缓存里已解析好，找不到目标时不写）
+func (fc *fieldConfig) write(structValue reflect.Value, label string, code any) {
+	if fc.targetFieldIndex >= 0 {
+		setTarget(structValue.Field(fc.targetFieldIndex), label, code)
+	}
+}
@@ -0,0 +1,92 @@
+package dict
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+)
+
+type sexLabel string
+
+type upperText struct{ s string }
+
+func (u *upperText) UnmarshalText(b []byte) error {
+	u.s = strings.ToUpper(string(b))
+	return nil
+}
+
+type colorLabel struct {
+	Code, Text, Color string
+}
+
+func (c *colorLabel) SetDictItem(code, label string) {
+	c.Code, c.Text = code, label
+	if code == "1" {
+		c.Color = "blue"
+	}
+}
+
+func TestNonStringTargets(t *testing.T) {
+	RegisterDict("sex", map[string]string{"1": "男", "2": "女"})
+	RegisterDict("color", map[string]string{"r": "red"})
+
+	type Label struct {
+		Code string
+		Text string
+	}
+	type DTO struct {
+		Sex      string `dict:"sex" dictField:"PtrName"`
+		PtrName  *string
+		Sex2     string `dict:"sex" dictField:"NullName"`
+		NullName sql.NullString
+		Sex3     string `dict:"sex" dictField:"Named"`
+		Named    sexLabel
+		Color    string `dict:"color" dictField:"Upper"`
+		Upper    upperText
+		Sex4     string `enum:"sexEnum" dictField:"Item"`
+		Item     Label
+		Sex5     string `dict:"sex" dictField:"Colored"`
+		Colored  *colorLabel
+	}
+	RegisterEnum("sexEnum", map[string]string{"2": "女"})
+	d := &DTO{Sex: "1", Sex2: "2", Sex3: "1", Color: "r", Sex4: "2", Sex5: "1"}
+	if err := Translate(d); err != nil {
+		t.Fatal(err)
+	}
+	if d.PtrName == nil || *d.PtrName != "男" {
+		t.Fatalf("*string 目标失败: %v", d.PtrName)
+	}
+	if !d.NullName.Valid || d.NullName.String != "女" || d.Named != "男" || d.Upper.s != "RED" {
+		t.Fatalf("Scanner / 自定义 string / TextUnmarshaler 目标失败: %+v", d)
+	}
+	if d.Item != (Label{Code: "2", Text: "女"}) {
+		t.Fatalf("结构体目标失败: %+v", d.Item)
+	}
+	if d.Colored == nil || *d.Colored != (colorLabel{"1", "男", "blue"}) {
+		t.Fatalf("DictItemSetter 目标失败: %+v", d.Colored)
+	}
+}
+
+func TestMissSetsNil(t *testing.T) {
+	RegisterDict("sex", map[string]string{"1": "男"})
+	RegisterEnum("sexEnum", map[string]string{"1": "男"})
+	old := "旧值"
+	type DTO struct {
+		Sex      string `dict:"sex,miss=nil" dictField:"SexName"`
+		SexName  *string
+		Sex2     *string `enum:"sexEnum,miss=nil" dictField:"Sex2Name"`
+		Sex2Name sql.NullString
+		Sex3     string `dict:"sex" dictField:"Sex3Name"`
+		Sex3Name *string
+	}
+	d := &DTO{Sex: "9", SexName: &old, Sex2Name: sql.NullString{String: "x", Valid: true}, Sex3: "9", Sex3Name: &old}
+	if err := Translate(d); err != nil {
+		t.Fatal(err)
+	}
+	if d.SexName != nil || d.Sex2Name.Valid {
+		t.Fatalf("miss=nil 应清空目标: %+v", d)
+	}
+	if d.Sex3Name != &old {
+		t.Fatalf("未配置 miss=nil 时不应改动目标")
+	}
+}
@@ -0,0 +1,132 @@
+package dict
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// DictEntry dictList 模板函数返回的一项
+type DictEntry struct {
+	Key   string
+	Label string
+}
+
+// FuncMap 模板函数（text/template 与 html/template 均可直接 Funcs），查的是与结构体翻译相同的注册表与结果缓存：
+//
+//	{{dict "sex" .Sex}}  {{enum "status" .Status}}  {{dictTable "region" .Code}}  {{dictTableTwo "type" .Code}}
+//	{{range dictList "sex"}}{{.Key}}={{.Label}}{{end}}
+//
+// 值可以是字符串、整数、指针、sql.Null* 等（归一化同源字段）。查不到时的策略与结构体翻译一致（管理器没有兜底译文）：
+// 未注册的字典、查不到的 key、nil 值一律输出空串，要占位用模板的 or：{{or (dict "sex" .Sex) "未知"}}；
+// DB 类后端出错时模板执行返回错误。mgr 为 nil 时用全局实例；DB 查询用 context.Background()，按请求查用 FuncMapContext
+func FuncMap(mgr *DictManager) map[string]any {
+	return FuncMapContext(context.Background(), mgr)
+}
+
+// FuncMapContext 同 FuncMap，DB 类查询带 ctx（取消、租户、语言、as-of 日期）
+func FuncMapContext(ctx context.Context, mgr *DictManager) map[string]any {
+	if mgr == nil {
+		mgr = defaultManager
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	t := &templateFuncs{ctx: ctx, dm: mgr}
+	return map[string]any{
+		"dict":         t.dict,
+		"enum":         t.enum,
+		"dictTable":    t.dictTable,
+		"dictTableTwo": t.dictTableTwo,
+		"dictList":     t.dictList,
+	}
+}
+
+type templateFuncs struct {
+	ctx context.Context
+	dm  *DictManager
+}
+
+func (t *templateFuncs) dict(name string, value any) string {
+	key, ok := templateKey(value)
+	if !ok {
+		return ""
+	}
+	return t.dm.loadReg().dicts[name][key]
+}
+
+func (t *templateFuncs) enum(name string, value any) (string, error) {
+	if l, ok := value.(DictLabeler); ok {
+		return l.DictLabel(), nil
+	}
+	key, ok := templateKey(value)
+	if !ok {
+		return "", nil
+	}
+	return DefaultEnumTranslator().Translate(key, "", name)
+}
+
+func (t *templateFuncs) dictTable(dictType string, value any) (string, error) {
+	return t.lookup(defaultDictTableManager, dictType, value)
+}
+
+func (t *templateFuncs) dictTableTwo(dictTypeCode string, value any) (string, error) {
+	return t.lookup(defaultDictTableTwoManager, dictTypeCode, value)
+}
+
+func (t *templateFuncs) lookup(mgr *lookupManager, group string, value any) (string, error) {
+	key, ok := templateKey(value)
+	if !ok {
+		return "", nil
+	}
+	label, err := newLookupTranslator(mgr, group).TranslateContext(t.ctx, key, "", "")
+	if err != nil {
+		return "", err
+	}
+	l, suffix, _ := splitDisabled(label)
+	return l + suffix, nil
+}
+
+// dictList 列出一个字典的全部项，按 key 排序（纯数字 key 按数值）：依次找内存字典、枚举、字典表（需后端实现 DictTableLoader）
+func (t *templateFuncs) dictList(name string) ([]DictEntry, error) {
+	m := t.dm.loadReg().dicts[name]
+	if m == nil {
+		m = GetEnum(name)
+	}
+	if m == nil {
+		var err error
+		if m, err = defaultDictTableManager.preload(t.ctx, []string{name}); err != nil {
+			return nil, err
+		}
+	}
+	entries := make([]DictEntry, 0, len(m))
+	for k, v := range m {
+		l, suffix, _ := splitDisabled(v)
+		entries = append(entries, DictEntry{Key: k, Label: l + suffix})
+	}
+	sort.Slice(entries, func(i, j int) bool { return keyLess(entries[i].Key, entries[j].Key) })
+	return entries, nil
+}
+
+// templateKey 模板传入的值转成字典 key；nil 与无效值返回 false
+func templateKey(value any) (string, bool) {
+	if s, ok := value.(string); ok {
+		return s, s != ""
+	}
+	rv := reflect.ValueOf(value)
+	if !rv.IsValid() {
+		return "", false
+	}
+	return sourceString(rv)
+}
+
+// keyLess 两个 key 都是整数时按数值比较，否则按字符串
+func keyLess(a, b string) bool {
+	x, errA := strconv.ParseInt(a, 10, 64)
+	y, errB := strconv.ParseInt(b, 10, 64)
+	if errA == nil && errB == nil {
+		return x < y
+	}
+	return a < b
+}
@@ -0,0 +1,60 @@
+package dict
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestFuncMap(t *testing.T) {
+	RegisterDict("sex", map[string]string{"1": "男", "2": "女"})
+	RegisterEnum("tplStatus", map[string]string{"1": "待支付", "10": "已完成", "2": "已支付"})
+	RegisterDictTableTranslator(DictTableTranslatorFunc(func(dictType, dictKey string) (string, error) {
+		if dictType == "tplRegion" && dictKey == "110000" {
+			return "北京<市>", nil
+		}
+		return "", nil
+	}))
+
+	status := 2
+	data := map[string]any{"Sex": "1", "Status": &status, "Code": 110000, "Missing": "9"}
+	const src = `{{dict "sex" .Sex}}|{{enum "tplStatus" .Status}}|{{dictTable "tplRegion" .Code}}|{{dict "sex" .Missing}}|` +
+		`{{range dictList "tplStatus"}}{{.Key}}={{.Label}};{{end}}`
+
+	var buf bytes.Buffer
+	tpl := template.Must(template.New("t").Funcs(FuncMap(nil)).Parse(src))
+	if err := tpl.Execute(&buf, data); err != nil {
+		t.Fatal(err)
+	}
+	if want := "男|已支付|北京<市>||1=待支付;2=已支付;10=已完成;"; buf.String() != want {
+		t.Fatalf("text/template 输出不对: %q", buf.String())
+	}
+
+	buf.Reset()
+	htpl := htmltemplate.Must(htmltemplate.New("t").Funcs(FuncMap(nil)).Parse(`<b>{{dictTable "tplRegion" .Code}}</b>`))
+	if err := htpl.Execute(&buf, data); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "<b>北京&lt;市&gt;</b>" {
+		t.Fatalf("html/template 应转义译文: %q", buf.String())
+	}
+
+	// 查不到：未注册的字典、查不到的 key、nil 值都输出空串，or 给占位
+	buf.Reset()
+	const miss = `[{{dict "nope" .Sex}}][{{enum "tplStatus" .Missing}}][{{dictTable "tplRegion" .Missing}}][{{dict "sex" .Nil}}]` +
+		`[{{or (dict "sex" .Missing) "未知"}}]`
+	if err := template.Must(template.New("t").Funcs(FuncMap(nil)).Parse(miss)).Execute(&buf, map[string]any{"Sex": "1", "Missing": "9", "Nil": nil}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "[][][][][未知]" {
+		t.Fatalf("查不到时应输出空串: %q", buf.String())
+	}
+
+	// 找不到的字典列表：字典表后端不支持整表加载时模板报错
+	err := template.Must(template.New("t").Funcs(FuncMap(nil)).Parse(`{{dictList "nope"}}`)).Execute(&buf, nil)
+	if err == nil || !strings.Contains(err.Error(), "preload") {
+		t.Fatalf("应返回整表加载错误: %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+package dict
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxTreeDepth 向上找祖先的最大层数：超过即截断，防止坏数据（过深或成环）拖垮翻译
+const maxTreeDepth = 32
+
+// TreeNode 树形字典的一个节点：名称 + 上级 key（根节点 Parent 为空）
+type TreeNode struct {
+	Label  string
+	Parent string
+}
+
+// RegisterTreeDict 注册内存树形字典（如行政区划：330106 -> {西湖区, 330100}）
+func RegisterTreeDict(name string, nodes map[string]TreeNode) {
+	defaultManager.RegisterTreeDict(name, nodes)
+}
+
+// RegisterTreeDict 注册内存树形字典（实例方法，并发安全）
+func (dm *DictManager) RegisterTreeDict(name string, nodes map[string]TreeNode) {
+	dm.updateReg(func(r *registry) { r.trees[name] = nodes })
+}
+
+// treeMode tree 标签输出什么
+type treeMode uint8
+
+const (
+	treePath     treeMode = iota // 完整路径：浙江省/杭州市/西湖区（默认）
+	treeRoot                     // 根节点名称
+	treeAncestor                 // 向上第 n 层祖先的名称（n=1 为上级）
+)
+
+// treeTranslator tree 标签的翻译器：tree:"region[,mode=path|root][,ancestor=N][,sep=/][,source=dictTable]"。
+// source 默认是 RegisterTreeDict 注册的内存树；source=dictTable 时节点来自字典表后端
+// （需实现 DictTableTreeTranslator，CreateDictTableTranslatorFromDB 在 TableConfig.ParentField 配置后支持），
+// 祖先按层批量查询，整条路径进字典表结果缓存。
+type treeTranslator struct {
+	dm    *DictManager
+	name  string
+	mode  treeMode
+	n     int
+	sep   string
+	db    bool
+	group string // 路径缓存分组（source=dictTable 时用）
+}
+
+// parseTreeTag 解析 tree 标签；ancestor 不是正整数时返回 nil（标签忽略）
+func (dm *DictManager) parseTreeTag(tag string) *treeTranslator {
+	name, opts := splitTag(tag)
+	if name == "" {
+		return nil
+	}
+	t := &treeTranslator{dm: dm, name: name, sep: "/", group: cacheGroup([]string{"\x00tree", name})}
+	if sep, ok := opts["sep"]; ok {
+		t.sep = sep
+	}
+	if opts["mode"] == "root" {
+		t.mode = treeRoot
+	}
+	if a, ok := opts["ancestor"]; ok {
+		n, err := strconv.Atoi(a)
+		if err != nil || n < 1 {
+			return nil
+		}
+		t.mode, t.n = treeAncestor, n
+	}
+	t.db = opts["source"] == "dictTable"
+	return t
+}
+
+func (t *treeTranslator) Translate(value any, fieldName string, tagValue string) (string, error) {
+	return t.TranslateContext(context.Background(), value, fieldName, tagValue)
+}
+
+func (t *treeTranslator) TranslateContext(ctx context.Context, value any, _ string, _ string) (string, error) {
+	key := fmt.Sprintf("%v", value)
+	if key == "" {
+		return "", nil
+	}
+	var path []string
+	if t.db {
+		v, ok := defaultDictTableManager.peek(ctx, t.group, key)
+		if !ok {
+			// 缓存只是优化：关闭缓存时直接用本次解析出的路径
+			paths, err := t.resolve(ctx, []string{key})
+			if err != nil {
+				return "", err
+			}
+			v, ok = paths[key]
+		}
+		if ok {
+			path = SplitCompositeKey(v)
+		}
+	} else {
+		nodes := t.dm.loadReg().trees[t.name]
+		path = treePathOf(key, func(k string) (TreeNode, bool) { n, ok := nodes[k]; return n, ok })
+	}
+	return t.render(path), nil
+}
+
+// render 按 mode 从根到叶的名称列表里取结果
+func (t *treeTranslator) render(path []string) string {
+	if len(path) == 0 {
+		return ""
+	}
+	switch t.mode {
+	case treeRoot:
+		return path[0]
+	case treeAncestor:
+		if i := len(path) - 1 - t.n; i >= 0 {
+			return path[i]
+		}
+		return ""
+	}
+	return strings.Join(path, t.sep)
+}
+
+// treePathOf 从 key 向上走到根，返回根到叶的名称；节点缺失即停止，成环 / 超过 maxTreeDepth 截断
+func treePathOf(key string, node func(string) (TreeNode, bool)) []string {
+	var rev []string
+	seen := make(map[string]struct{}, 8)
+	for cur := key; cur != "" && len(rev) < maxTreeDepth; {
+		if _, loop := seen[cur]; loop {
+			break
+		}
+		seen[cur] = struct{}{}
+		n, ok := node(cur)
+		if !ok {
+			break
+		}
+		rev = append(rev, n.Label)
+		cur = n.Parent
+	}
+	for i, j := 0, len(rev)-1; i < j; i, j = i+1, j-1 {
+		rev[i], rev[j] = rev[j], rev[i]
+	}
+	return rev
+}
+
+func (t *treeTranslator) scope(ctx context.Context) string {
+	if !t.db {
+		return ""
+	}
+	return defaultDictTableManager.scope(ctx)
+}
+
+// prefetch 解析一批 key 的完整路径并缓存（仅 source=dictTable）
+func (t *treeTranslator) prefetch(ctx context.Context, keys []string) error {
+	if !t.db {
+		return nil
+	}
+	_, err := t.resolve(ctx, keys)
+	return err
+}
+
+// resolve 解析未命中缓存的 key 的完整路径（key -> CompositeKey 编码的路径）并写入缓存：按层向上，
+// 每层一次 QueryNodes，最多 maxTreeDepth 层。返回值不依赖缓存是否开启
+func (t *treeTranslator) resolve(ctx context.Context, keys []string) (map[string]string, error) {
+	m := defaultDictTableManager
+	b := m.backend.Load()
+	if b == nil {
+		return nil, fmt.Errorf("%s translator not registered", m.name)
+	}
+	if b.nodes == nil {
+		return nil, fmt.Errorf("%s translator does not support tree dictionaries (implement DictTableTreeTranslator)", m.name)
+	}
+	pending := make([]string, 0, len(keys))
+	for _, k := range dedupeKeys(keys) {
+		if _, ok := m.peek(ctx, t.group, k); !ok {
+			pending = append(pending, k)
+		}
+	}
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	nodes := make(map[string]TreeNode)
+	frontier := pending
+	for depth := 0; len(frontier) > 0 && depth < maxTreeDepth; depth++ {
+		got, err := b.nodes(ctx, []string{t.name}, frontier)
+		if err != nil {
+			return nil, err
+		}
+		next := make([]string, 0, len(got))
+		for k, n := range got {
+			nodes[k] = n
+			if _, known := nodes[n.Parent]; n.Parent != "" && !known {
+				next = append(next, n.Parent)
+			}
+		}
+		frontier = dedupeKeys(next)
+	}
+
+	prefix := b.scopedGroup(ctx, t.group)
+	paths := make(map[string]string, len(pending))
+	for _, k := range pending {
+		if path := treePathOf(k, func(k string) (TreeNode, bool) { n, ok := nodes[k]; return n, ok }); len(path) > 0 {
+			paths[k] = CompositeKey(path...)
+			m.cache.set(prefix+":"+k, paths[k])
+		}
+	}
+	return paths, nil
+}
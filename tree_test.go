@@ -0,0 +1,119 @@
+package dict
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+var regionNodes = map[string]TreeNode{
+	"330000": {Label: "浙江省"},
+	"330100": {Label: "杭州市", Parent: "330000"},
+	"330106": {Label: "西湖区", Parent: "330100"},
+	"330102": {Label: "上城区", Parent: "330100"},
+	"990000": {Label: "环", Parent: "990001"},
+	"990001": {Label: "状", Parent: "990000"},
+}
+
+func TestTreeDictMemory(t *testing.T) {
+	dm := NewDictManager()
+	dm.RegisterTreeDict("region", regionNodes)
+	type Addr struct {
+		Code     string `tree:"region" dictField:"Path"`
+		Path     string
+		Code2    string `tree:"region,sep=-" dictField:"Path2"`
+		Path2    string
+		Code3    string `tree:"region,ancestor=1" dictField:"City"`
+		City     string
+		Code4    string `tree:"region,mode=root" dictField:"Province"`
+		Province string
+	}
+	a := &Addr{Code: "330106", Code2: "330106", Code3: "330106", Code4: "330106"}
+	if err := dm.Translate(a); err != nil {
+		t.Fatal(err)
+	}
+	if a.Path != "浙江省/杭州市/西湖区" || a.Path2 != "浙江省-杭州市-西湖区" || a.City != "杭州市" || a.Province != "浙江省" {
+		t.Fatalf("树形字典翻译不对: %+v", a)
+	}
+
+	// 成环数据截断，不死循环
+	c := &Addr{Code: "990000"}
+	if err := dm.Translate(c); err != nil || c.Path != "状/环" {
+		t.Fatalf("成环数据应截断: %v %q", err, c.Path)
+	}
+	if dm.parseTreeTag("region,ancestor=0") != nil {
+		t.Fatal("ancestor 必须是正整数")
+	}
+}
+
+// 字典表后端的树：按层批量取节点
+type treeDictTable struct {
+	countingDictTable
+	nodeCalls int64
+}
+
+func (d *treeDictTable) QueryNodes(_ context.Context, dictType string, keys []string) (map[string]TreeNode, error) {
+	atomic.AddInt64(&d.nodeCalls, 1)
+	out := map[string]TreeNode{}
+	for _, k := range keys {
+		if n, ok := regionNodes[k]; ok && dictType == "region" {
+			out[k] = n
+		}
+	}
+	return out, nil
+}
+
+func TestTreeDictFromDictTable(t *testing.T) {
+	be := &treeDictTable{}
+	resetDictTableFor(t, be)
+	type Addr struct {
+		Code string `tree:"region,source=dictTable" dictField:"Path"`
+		Path string
+	}
+	rows := make([]Addr, 20)
+	for i := range rows {
+		rows[i].Code = []string{"330106", "330102"}[i%2]
+	}
+	if err := Translate(&rows); err != nil {
+		t.Fatal(err)
+	}
+	if rows[0].Path != "浙江省/杭州市/西湖区" || rows[1].Path != "浙江省/杭州市/上城区" {
+		t.Fatalf("路径不对: %q %q", rows[0].Path, rows[1].Path)
+	}
+	// 3 层：区 → 市 → 省，每层一次查询；翻译阶段全部命中路径缓存
+	if n := atomic.LoadInt64(&be.nodeCalls); n != 3 {
+		t.Fatalf("期望按层 3 次节点查询，实际 %d", n)
+	}
+	a := &Addr{Code: "330106"}
+	if err := Translate(a); err != nil || a.Path != "浙江省/杭州市/西湖区" || atomic.LoadInt64(&be.nodeCalls) != 3 {
+		t.Fatalf("整条路径应已缓存: %v %q calls=%d", err, a.Path, be.nodeCalls)
+	}
+}
+
+func TestTreeDictFromDictTableNoCache(t *testing.T) {
+	be := &treeDictTable{}
+	resetDictTableFor(t, be)
+	EnableDictTableCache(false)
+	type Addr struct {
+		Code string `tree:"region,source=dictTable" dictField:"Path"`
+		Path string
+	}
+	a := &Addr{Code: "330106"}
+	if err := Translate(a); err != nil || a.Path != "浙江省/杭州市/西湖区" {
+		t.Fatalf("关闭字典表缓存时路径应直接取查询结果: %v %q", err, a.Path)
+	}
+	rows := []Addr{{Code: "330102"}, {Code: "330106"}}
+	if err := Translate(&rows); err != nil || rows[0].Path != "浙江省/杭州市/上城区" || rows[1].Path != "浙江省/杭州市/西湖区" {
+		t.Fatalf("关闭缓存时切片翻译不对: %v %+v", err, rows)
+	}
+}
+
+func TestBuildQueryNodes(t *testing.T) {
+	tc := DefaultTableConfig("sys_region")
+	tc.ParentField = "parent_code"
+	q, args := tc.BuildQueryNodes("region", []string{"1", "2"})
+	if !strings.HasPrefix(q, "SELECT dict_key, dict_value, parent_code FROM sys_region WHERE dict_type = ? AND dict_key IN (?, ?)") || len(args) != 4 {
+		t.Fatalf("BuildQueryNodes: %s %v", q, args)
+	}
+}
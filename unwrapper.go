@@ -17,9 +17,14 @@ func (f UnWrapperFunc) UnWrap(value any) (any, error) {
 	return f(value)
 }
 
-// RegisterUnWrapper 注册解包器
+// RegisterUnWrapper 注册解包器（全局实例）
 func RegisterUnWrapper(unwrapper UnWrapper) {
-	defaultManager.unwrappers = append(defaultManager.unwrappers, unwrapper)
+	defaultManager.RegisterUnWrapper(unwrapper)
+}
+
+// RegisterUnWrapper 注册解包器（实例方法）：解包器按实例登记，NewDictManager 的实例（如 WithHTTPManager 用的）要在它自己上注册
+func (dm *DictManager) RegisterUnWrapper(unwrapper UnWrapper) {
+	dm.unwrappers = append(dm.unwrappers, unwrapper)
 }
 
 // tryUnwrap 尝试解包